@@ -0,0 +1,47 @@
+package main
+
+// Bridge flattens a channel of channels into one stream, draining each
+// inner channel fully before moving to the next.
+func Bridge[T any](done <-chan struct{}, chans <-chan (<-chan T)) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			var stream <-chan T
+			select {
+			case s, ok := <-chans:
+				if !ok {
+					return
+				}
+				stream = s
+			case <-done:
+				return
+			}
+			for v := range OrDone(done, stream) {
+				select {
+				case out <- v:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// Batches is a helper for the Bridge demo: it produces one inner channel
+// per batch.
+func Batches[T any](done <-chan struct{}, batches ...[]T) <-chan (<-chan T) {
+	out := make(chan (<-chan T))
+	go func() {
+		defer close(out)
+		for _, b := range batches {
+			select {
+			case out <- Generate(done, b...):
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}
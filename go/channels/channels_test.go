@@ -0,0 +1,120 @@
+package main
+
+import (
+	"runtime"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+// collect drains ch into a slice.
+func collect[T any](ch <-chan T) []T {
+	var out []T
+	for v := range ch {
+		out = append(out, v)
+	}
+	return out
+}
+
+// checkNoLeak fails the test if goroutines started during it are still
+// running shortly after it finishes.
+func checkNoLeak(t *testing.T) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before {
+			if time.Now().After(deadline) {
+				t.Errorf("goroutines leaked: %d before, %d after", before, runtime.NumGoroutine())
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	checkNoLeak(t)
+	done := make(chan struct{})
+	defer close(done)
+	if got := collect(Generate(done, 1, 2, 3)); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Generate = %v", got)
+	}
+}
+
+func TestRepeatTake(t *testing.T) {
+	checkNoLeak(t)
+	done := make(chan struct{})
+	defer close(done)
+	got := collect(Take(done, Repeat(done, 1, 2), 5))
+	if !slices.Equal(got, []int{1, 2, 1, 2, 1}) {
+		t.Errorf("Take(Repeat) = %v", got)
+	}
+	if got := collect(Take(done, Generate(done, 1), 3)); !slices.Equal(got, []int{1}) {
+		t.Errorf("Take past end = %v", got)
+	}
+}
+
+func TestMergeTwo(t *testing.T) {
+	checkNoLeak(t)
+	done := make(chan struct{})
+	defer close(done)
+	got := collect(MergeTwo(done, Generate(done, 1, 3), Generate(done, 2, 4, 6)))
+	sort.Ints(got)
+	if !slices.Equal(got, []int{1, 2, 3, 4, 6}) {
+		t.Errorf("MergeTwo = %v", got)
+	}
+}
+
+func TestOrDoneStops(t *testing.T) {
+	checkNoLeak(t)
+	done := make(chan struct{})
+	defer close(done)
+	stop := make(chan struct{})
+	n := 0
+	for range OrDone(stop, Repeat(done, 0)) {
+		n++
+		if n == 10 {
+			close(stop)
+		}
+	}
+	if n < 10 {
+		t.Errorf("received %d values, want at least 10", n)
+	}
+}
+
+func TestTee(t *testing.T) {
+	checkNoLeak(t)
+	done := make(chan struct{})
+	defer close(done)
+	a, b := Tee(done, Generate(done, 1, 2, 3))
+	var left, right []int
+	for v := range a {
+		left = append(left, v)
+		right = append(right, <-b)
+	}
+	if !slices.Equal(left, []int{1, 2, 3}) || !slices.Equal(right, left) {
+		t.Errorf("Tee = %v / %v", left, right)
+	}
+}
+
+func TestBridge(t *testing.T) {
+	checkNoLeak(t)
+	done := make(chan struct{})
+	defer close(done)
+	got := collect(Bridge(done, Batches(done, []int{1, 2}, nil, []int{3})))
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Bridge = %v", got)
+	}
+}
+
+func TestBridgeCancel(t *testing.T) {
+	checkNoLeak(t)
+	done := make(chan struct{})
+	out := Bridge(done, Batches(done, []int{1, 2, 3}, []int{4}))
+	<-out
+	close(done)
+	for range out {
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/XianingY/learn/go/channels
+
+go 1.22
@@ -0,0 +1,48 @@
+package main
+
+import "fmt"
+
+func main() {
+	done := make(chan struct{})
+	defer close(done)
+
+	fmt.Println("== generator ==")
+	for v := range Generate(done, 1, 2, 3) {
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+	for v := range Take(done, Repeat(done, "a", "b"), 5) {
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+
+	fmt.Println("\n== nil channels in select ==")
+	total := 0
+	for v := range MergeTwo(done, Generate(done, 1, 2), Generate(done, 10, 20, 30)) {
+		total += v
+	}
+	fmt.Println("merged sum:", total)
+
+	fmt.Println("\n== or-done ==")
+	stop := make(chan struct{})
+	count := 0
+	for range OrDone(stop, Repeat(done, 0)) {
+		count++
+		if count == 3 {
+			close(stop)
+		}
+	}
+	fmt.Println("infinite stream stopped once stop was closed")
+
+	fmt.Println("\n== tee ==")
+	left, right := Tee(done, Generate(done, "x", "y"))
+	for v := range left {
+		fmt.Printf("left=%s right=%s\n", v, <-right)
+	}
+
+	fmt.Println("\n== bridge ==")
+	for v := range Bridge(done, Batches(done, []int{1, 2}, []int{3}, []int{4, 5})) {
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+}
@@ -0,0 +1,35 @@
+package main
+
+// MergeTwo reads from a and b until both are closed. A receive from a nil
+// channel blocks forever, so setting a finished channel to nil removes its
+// case from the select without any extra bookkeeping.
+func MergeTwo[T any](done <-chan struct{}, a, b <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for a != nil || b != nil {
+			var v T
+			var ok bool
+			select {
+			case v, ok = <-a:
+				if !ok {
+					a = nil
+					continue
+				}
+			case v, ok = <-b:
+				if !ok {
+					b = nil
+					continue
+				}
+			case <-done:
+				return
+			}
+			select {
+			case out <- v:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}
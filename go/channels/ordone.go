@@ -0,0 +1,27 @@
+package main
+
+// OrDone wraps in so that a plain "for v := range OrDone(done, in)" loop
+// also stops when done is closed. Without it every consumer has to write
+// the nested select itself.
+func OrDone[T any](done <-chan struct{}, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-done:
+				return
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- v:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,28 @@
+package main
+
+// Tee copies every value from in to both outputs. A value is only
+// considered delivered once both readers have received it, so the slower
+// reader sets the pace.
+func Tee[T any](done <-chan struct{}, in <-chan T) (<-chan T, <-chan T) {
+	out1 := make(chan T)
+	out2 := make(chan T)
+	go func() {
+		defer close(out1)
+		defer close(out2)
+		for v := range OrDone(done, in) {
+			// Local copies so each can be set to nil once it has been sent.
+			o1, o2 := out1, out2
+			for i := 0; i < 2; i++ {
+				select {
+				case o1 <- v:
+					o1 = nil
+				case o2 <- v:
+					o2 = nil
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+	return out1, out2
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// Counts holds the result for one file.
+type Counts struct {
+	Lines int
+	Blank int
+	Bytes int64
+}
+
+func (c *Counts) add(o Counts) {
+	c.Lines += o.Lines
+	c.Blank += o.Blank
+	c.Bytes += o.Bytes
+}
+
+// CountReadFile loads the whole file with os.ReadFile. It is the simplest
+// option and fine for small files, but memory use grows with file size.
+func CountReadFile(path string) (Counts, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Counts{}, err
+	}
+	return countBytes(data), nil
+}
+
+func countBytes(data []byte) Counts {
+	c := Counts{Bytes: int64(len(data))}
+	for len(data) > 0 {
+		line, rest, _ := bytes.Cut(data, []byte("\n"))
+		c.Lines++
+		if len(bytes.TrimSpace(line)) == 0 {
+			c.Blank++
+		}
+		data = rest
+	}
+	return c
+}
+
+// CountStream reads through a bufio.Reader, so memory use stays constant
+// however large the input is. bufio.Scanner would be shorter, but it fails
+// with ErrTooLong on lines over its buffer size; ReadSlice plus a check
+// for ErrBufferFull handles lines of any length.
+func CountStream(r io.Reader) (Counts, error) {
+	br := bufio.NewReaderSize(r, 64*1024)
+	var c Counts
+	blank := true
+	inLine := false
+	for {
+		chunk, err := br.ReadSlice('\n')
+		c.Bytes += int64(len(chunk))
+		if len(chunk) > 0 {
+			inLine = true
+			if len(bytes.TrimSpace(chunk)) > 0 {
+				blank = false
+			}
+		}
+		if err == nil || (errors.Is(err, io.EOF) && inLine) {
+			// A complete line, or a final line with no trailing newline.
+			c.Lines++
+			if blank {
+				c.Blank++
+			}
+			blank, inLine = true, false
+		}
+		switch {
+		case err == nil, errors.Is(err, bufio.ErrBufferFull):
+			continue
+		case errors.Is(err, io.EOF):
+			return c, nil
+		default:
+			return c, err
+		}
+	}
+}
+
+// CountFile opens a file from fsys and streams it.
+func CountFile(fsys fs.FS, name string) (Counts, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return Counts{}, err
+	}
+	defer f.Close()
+	return CountStream(f)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCountStream(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want Counts
+	}{
+		{"empty", "", Counts{}},
+		{"one line", "hello\n", Counts{Lines: 1, Bytes: 6}},
+		{"no trailing newline", "a\nb", Counts{Lines: 2, Bytes: 3}},
+		{"blank lines", "a\n\n  \nb\n", Counts{Lines: 4, Blank: 2, Bytes: 8}},
+		{"only newline", "\n", Counts{Lines: 1, Blank: 1, Bytes: 1}},
+		{"crlf", "a\r\n\r\n", Counts{Lines: 2, Blank: 1, Bytes: 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CountStream(strings.NewReader(tt.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("CountStream = %+v, want %+v", got, tt.want)
+			}
+			// Both strategies must agree.
+			if b := countBytes([]byte(tt.in)); b != tt.want {
+				t.Errorf("countBytes = %+v, want %+v", b, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountStreamLongLine(t *testing.T) {
+	// Longer than the 64 KiB reader buffer, which bufio.Scanner's default
+	// settings would reject with ErrTooLong.
+	in := strings.Repeat("x", 200_000) + "\n" + strings.Repeat(" ", 100_000) + "\nend"
+	got, err := CountStream(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Counts{Lines: 3, Blank: 1, Bytes: int64(len(in))}
+	if got != want {
+		t.Errorf("CountStream = %+v, want %+v", got, want)
+	}
+}
+
+func TestCountReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "f.txt")
+	if err := os.WriteFile(path, []byte("one\n\nthree\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := CountReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Counts{Lines: 3, Blank: 1, Bytes: 11}); got != want {
+		t.Errorf("CountReadFile = %+v, want %+v", got, want)
+	}
+	if _, err := CountReadFile(filepath.Join(t.TempDir(), "missing")); !os.IsNotExist(err) {
+		t.Errorf("missing file: err = %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+)
+
+// The files are read at build time and compiled into the binary, so the
+// tool works no matter which directory it is run from.
+
+//go:embed assets/usage.txt
+var usage string
+
+//go:embed assets/skip.txt
+var skipList string
+
+// defaultSkips parses the embedded skip list, ignoring blanks and comments.
+func defaultSkips() map[string]bool {
+	skips := make(map[string]bool)
+	sc := bufio.NewScanner(strings.NewReader(skipList))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		skips[line] = true
+	}
+	return skips
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+func main() {
+	exts := flag.String("ext", ".go", "comma-separated extensions to count, empty for all files")
+	mode := flag.String("mode", "stream", "how to read files: stream or readall")
+	out := flag.String("out", "", "write the report to this file (atomically) instead of stdout")
+	listSkips := flag.Bool("list-skips", false, "print the built-in skip list and exit")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *mode != "stream" && *mode != "readall" {
+		log.Fatalf("unknown -mode %q: want stream or readall", *mode)
+	}
+
+	skips := defaultSkips()
+	if *listSkips {
+		names := make([]string, 0, len(skips))
+		for name := range skips {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		fmt.Println(strings.Join(names, "\n"))
+		return
+	}
+
+	opts := WalkOptions{Skip: skips}
+	for _, e := range strings.Split(*exts, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			opts.Exts = append(opts.Exts, e)
+		}
+	}
+
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	var results []FileCounts
+	for _, dir := range dirs {
+		opts.OnSkip = func(p string, err error) {
+			fmt.Fprintf(os.Stderr, "skipping %s: %v\n", filepath.Join(dir, filepath.FromSlash(p)), err)
+		}
+		rs, err := CountTree(os.DirFS(dir), ".", opts)
+		if err != nil {
+			log.Fatalf("walk %s: %v", dir, err)
+		}
+		for _, r := range rs {
+			full := filepath.Join(dir, filepath.FromSlash(r.Path))
+			if *mode == "readall" {
+				// Re-count from disk to show the whole-file approach gives
+				// the same answer.
+				c, err := CountReadFile(full)
+				if err != nil {
+					log.Fatal(err)
+				}
+				r.Counts = c
+			}
+			r.Path = full
+			results = append(results, r)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Lines > results[j].Lines })
+
+	var sb strings.Builder
+	writeReport(&sb, results)
+
+	if *out == "" {
+		fmt.Print(sb.String())
+		return
+	}
+	if err := WriteFileAtomic(*out, []byte(sb.String()), 0o644); err != nil {
+		log.Fatalf("write report: %v", err)
+	}
+	fmt.Printf("wrote %d files to %s\n", len(results), *out)
+}
+
+func writeReport(w io.Writer, results []FileCounts) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "lines\tblank\tbytes\t\tfile")
+	var total Counts
+	for _, r := range results {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t\t%s\n", r.Lines, r.Blank, r.Bytes, r.Path)
+		total.add(r.Counts)
+	}
+	fmt.Fprintf(tw, "%d\t%d\t%d\t\t%s\n", total.Lines, total.Blank, total.Bytes, fmt.Sprintf("total (%d files)", len(results)))
+	tw.Flush()
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// FileCounts pairs a path (slash-separated, relative to the walked FS)
+// with its counts.
+type FileCounts struct {
+	Path string
+	Counts
+}
+
+// WalkOptions controls which files are counted.
+type WalkOptions struct {
+	// Exts limits counting to these extensions (with the dot). Empty
+	// means every regular file.
+	Exts []string
+	// Skip lists directory names that are not descended into.
+	Skip map[string]bool
+	// OnSkip, if set, is called for each file or directory below the
+	// root that cannot be read and is left out of the results.
+	OnSkip func(path string, err error)
+}
+
+func (o WalkOptions) skip(p string, err error) {
+	if o.OnSkip != nil {
+		o.OnSkip(p, err)
+	}
+}
+
+func (o WalkOptions) wants(name string) bool {
+	if len(o.Exts) == 0 {
+		return true
+	}
+	ext := path.Ext(name)
+	for _, e := range o.Exts {
+		if strings.EqualFold(e, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// CountTree walks fsys from root with fs.WalkDir. Taking an fs.FS rather
+// than a path means tests can pass an in-memory fstest.MapFS, and callers
+// can pass os.DirFS, an embed.FS, or a zip archive.
+func CountTree(fsys fs.FS, root string, opts WalkOptions) ([]FileCounts, error) {
+	var results []FileCounts
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// An unreadable root is fatal; anything below it is skipped.
+			if p == root {
+				return err
+			}
+			opts.skip(p, err)
+			return nil
+		}
+		if d.IsDir() {
+			if p != root && opts.Skip[d.Name()] {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		// Symlinks, devices, and sockets are not counted.
+		if !d.Type().IsRegular() || !opts.wants(d.Name()) {
+			return nil
+		}
+		c, err := CountFile(fsys, p)
+		if err != nil {
+			// Unreadable files below the root are skipped, like unreadable
+			// directories above.
+			opts.skip(p, err)
+			return nil
+		}
+		results = append(results, FileCounts{Path: p, Counts: c})
+		return nil
+	})
+	return results, err
+}
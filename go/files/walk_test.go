@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestCountTree(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go":                 {Data: []byte("package main\n\nfunc main() {}\n")},
+		"README.md":               {Data: []byte("# hi\n")},
+		"pkg/util.go":             {Data: []byte("package pkg\n")},
+		"vendor/dep/dep.go":       {Data: []byte("package dep\n")},
+		"node_modules/x/index.go": {Data: []byte("x\n")},
+		"pkg/deeper/nested/z.GO":  {Data: []byte("a\nb\n")},
+		"pkg/deeper/nested/notes": {Data: []byte("a\n")},
+	}
+
+	got, err := CountTree(fsys, ".", WalkOptions{Exts: []string{".go"}, Skip: defaultSkips()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]Counts{
+		"main.go":                {Lines: 3, Blank: 1, Bytes: 29},
+		"pkg/util.go":            {Lines: 1, Bytes: 12},
+		"pkg/deeper/nested/z.GO": {Lines: 2, Bytes: 4},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d files, want %d: %+v", len(got), len(want), got)
+	}
+	for _, fc := range got {
+		if w, ok := want[fc.Path]; !ok || w != fc.Counts {
+			t.Errorf("%s: got %+v, want %+v (present=%v)", fc.Path, fc.Counts, w, ok)
+		}
+	}
+}
+
+func TestCountTreeAllFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt":   {Data: []byte("1\n")},
+		"b/c.bin": {Data: []byte{0, 1, 2}},
+	}
+	got, err := CountTree(fsys, ".", WalkOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("got %d files, want 2", len(got))
+	}
+}
+
+// failingFS wraps an fs.FS and fails to open one file.
+type failingFS struct {
+	fs.FS
+	bad string
+}
+
+func (f failingFS) Open(name string) (fs.File, error) {
+	if name == f.bad {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	}
+	return f.FS.Open(name)
+}
+
+func TestCountTreeSkipsUnreadableFiles(t *testing.T) {
+	fsys := failingFS{
+		FS: fstest.MapFS{
+			"a.go":      {Data: []byte("a\n")},
+			"secret.go": {Data: []byte("b\n")},
+			"z.go":      {Data: []byte("c\n")},
+		},
+		bad: "secret.go",
+	}
+	var skipped []string
+	opts := WalkOptions{OnSkip: func(p string, err error) {
+		if !errors.Is(err, fs.ErrPermission) {
+			t.Errorf("OnSkip(%q) err = %v, want ErrPermission", p, err)
+		}
+		skipped = append(skipped, p)
+	}}
+	got, err := CountTree(fsys, ".", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Path != "a.go" || got[1].Path != "z.go" {
+		t.Errorf("CountTree = %+v, want a.go and z.go", got)
+	}
+	if len(skipped) != 1 || skipped[0] != "secret.go" {
+		t.Errorf("skipped = %q, want [secret.go]", skipped)
+	}
+}
+
+func TestCountTreeMissingRoot(t *testing.T) {
+	if _, err := CountTree(fstest.MapFS{}, "nope", WalkOptions{}); err == nil {
+		t.Error("expected an error for a missing root")
+	}
+}
+
+func TestCountTreeOnDisk(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, body := range map[string]string{"x.go": "a\nb\n", ".git/config.go": "skip\n"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := CountTree(os.DirFS(dir), ".", WalkOptions{Exts: []string{".go"}, Skip: defaultSkips()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Path != "x.go" || got[0].Lines != 2 {
+		t.Errorf("CountTree = %+v", got)
+	}
+}
+
+func TestDefaultSkips(t *testing.T) {
+	skips := defaultSkips()
+	if !skips[".git"] || !skips["vendor"] {
+		t.Errorf("defaultSkips = %v", skips)
+	}
+	for name := range skips {
+		if name == "" || name[0] == '#' {
+			t.Errorf("comment or blank line parsed as a skip: %q", name)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+// Number is a constraint with a type set. The ~ means "any type whose
+// underlying type is", so named types like Celsius below satisfy it too.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Ordered is the same idea as cmp.Ordered, spelled out so the type set is visible.
+type Ordered interface {
+	Number | ~string
+}
+
+// Celsius is a named type; without ~ in Number it could not be summed.
+type Celsius float64
+
+// Sum adds up any slice of numbers.
+func Sum[T Number](xs []T) T {
+	var total T
+	for _, x := range xs {
+		total += x
+	}
+	return total
+}
+
+// Max returns the largest element and false for an empty slice.
+func Max[T Ordered](xs []T) (T, bool) {
+	var zero T
+	if len(xs) == 0 {
+		return zero, false
+	}
+	m := xs[0]
+	for _, x := range xs[1:] {
+		if x > m {
+			m = x
+		}
+	}
+	return m, true
+}
+
+// Map applies f to every element. Two type parameters: input and output.
+func Map[T, U any](xs []T, f func(T) U) []U {
+	out := make([]U, 0, len(xs))
+	for _, x := range xs {
+		out = append(out, f(x))
+	}
+	return out
+}
+
+// Filter keeps the elements for which keep returns true.
+func Filter[T any](xs []T, keep func(T) bool) []T {
+	var out []T
+	for _, x := range xs {
+		if keep(x) {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// Index uses the built-in comparable constraint so == is allowed.
+func Index[T comparable](xs []T, v T) int {
+	for i, x := range xs {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// Set is a generic type; comparable is required because T is a map key.
+type Set[T comparable] map[T]struct{}
+
+// NewSet builds a set from the given values.
+func NewSet[T comparable](vs ...T) Set[T] {
+	s := make(Set[T], len(vs))
+	for _, v := range vs {
+		s.Add(v)
+	}
+	return s
+}
+
+func (s Set[T]) Add(v T)      { s[v] = struct{}{} }
+func (s Set[T]) Has(v T) bool { _, ok := s[v]; return ok }
+func (s Set[T]) Len() int     { return len(s) }
+func (s Set[T]) Remove(v T)   { delete(s, v) }
+
+// Stringish mixes a method requirement with a type set. Only types whose
+// underlying type is string AND that have a Label method satisfy it.
+type Stringish interface {
+	~string
+	Label() string
+}
+
+type Color string
+
+func (c Color) Label() string { return "color:" + string(c) }
+
+// Labels works for any Stringish; it can both call Label and convert to string.
+func Labels[T Stringish](xs []T) []string {
+	out := make([]string, len(xs))
+	for i, x := range xs {
+		out[i] = x.Label() + "(" + string(x) + ")"
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	if got := Sum([]int{1, 2, 3}); got != 6 {
+		t.Errorf("Sum ints = %d, want 6", got)
+	}
+	if got := Sum([]Celsius{1.5, 2.5}); got != 4 {
+		t.Errorf("Sum Celsius = %v, want 4", got)
+	}
+	if got := Sum[uint8](nil); got != 0 {
+		t.Errorf("Sum nil = %d, want 0", got)
+	}
+}
+
+func TestMax(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     []string
+		want   string
+		wantOK bool
+	}{
+		{"empty", nil, "", false},
+		{"single", []string{"a"}, "a", true},
+		{"many", []string{"pear", "apple", "plum"}, "plum", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Max(tt.in)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Max(%v) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestMapFilterIndex(t *testing.T) {
+	doubled := Map([]int{1, 2, 3}, func(n int) int { return n * 2 })
+	if !slices.Equal(doubled, []int{2, 4, 6}) {
+		t.Errorf("Map = %v", doubled)
+	}
+	odd := Filter([]int{1, 2, 3}, func(n int) bool { return n%2 == 1 })
+	if !slices.Equal(odd, []int{1, 3}) {
+		t.Errorf("Filter = %v", odd)
+	}
+	if i := Index([]string{"a", "b"}, "b"); i != 1 {
+		t.Errorf("Index = %d, want 1", i)
+	}
+	if i := Index([]string{"a"}, "z"); i != -1 {
+		t.Errorf("Index missing = %d, want -1", i)
+	}
+}
+
+func TestSet(t *testing.T) {
+	s := NewSet(1, 2, 2, 3)
+	if s.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", s.Len())
+	}
+	s.Remove(2)
+	if s.Has(2) || !s.Has(3) {
+		t.Errorf("unexpected membership after Remove: %v", s)
+	}
+}
+
+func TestLabels(t *testing.T) {
+	got := Labels([]Color{"red"})
+	if !slices.Equal(got, []string{"color:red(red)"}) {
+		t.Errorf("Labels = %v", got)
+	}
+}
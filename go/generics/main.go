@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func main() {
+	fmt.Println("== functions and constraints ==")
+	fmt.Println("Sum ints:", Sum([]int{1, 2, 3}))
+	fmt.Println("Sum Celsius:", Sum([]Celsius{20.5, 21.0}))
+	if m, ok := Max([]string{"pear", "apple", "plum"}); ok {
+		fmt.Println("Max string:", m)
+	}
+	fmt.Println("Map:", Map([]int{1, 2, 3}, func(n int) string { return strings.Repeat("*", n) }))
+	fmt.Println("Filter evens:", Filter([]int{1, 2, 3, 4}, func(n int) bool { return n%2 == 0 }))
+	fmt.Println("Index of 'c':", Index([]rune("abc"), 'c'))
+	s := NewSet("go", "rust", "go")
+	fmt.Println("Set len:", s.Len(), "has rust:", s.Has("rust"))
+	fmt.Println("Labels:", Labels([]Color{"red", "blue"}))
+
+	fmt.Println("\n== Pair ==")
+	p := MakePair("answer", 42)
+	fmt.Println(p, "swapped:", p.Swap())
+	fmt.Println("Zip:", Zip([]string{"a", "b", "c"}, []int{1, 2}))
+	entries := Entries(map[string]int{"x": 1, "y": 2})
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	fmt.Println("Entries:", entries)
+
+	fmt.Println("\n== Optional ==")
+	found := Find([]int{3, 8, 11}, func(n int) bool { return n > 5 })
+	missing := Find([]int{3, 8, 11}, func(n int) bool { return n > 50 })
+	fmt.Println(found, missing, "fallback:", missing.OrElse(-1))
+	fmt.Println("MapOptional:", MapOptional(found, func(n int) string { return fmt.Sprint(n * 2) }))
+
+	fmt.Println("\n== pitfalls ==")
+	fmt.Printf("Zero[int]()=%v Zero[string]()=%q\n", Zero[int](), Zero[string]())
+	if _, err := SafeEqual[any]([]int{1}, []int{1}); err != nil {
+		fmt.Println("Equal[any] on slices:", err)
+	}
+	fmt.Printf("Filter with no matches: %#v, NonNil: %#v\n",
+		Filter([]int{1}, func(int) bool { return false }),
+		NonNil(Filter([]int{1}, func(int) bool { return false })))
+	fmt.Println("SumAsFloat:", SumAsFloat([]Celsius{1.5, 2.5}))
+	fmt.Printf("Clone keeps named type: %T\n", Clone(Readings{1, 2}))
+}
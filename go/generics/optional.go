@@ -0,0 +1,61 @@
+package main
+
+import "fmt"
+
+// Optional makes "no value" explicit instead of relying on nil pointers
+// or magic zero values.
+type Optional[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some wraps a present value.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, ok: true}
+}
+
+// None has to be called with an explicit type argument, e.g. None[int](),
+// because there is nothing to infer T from.
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+func (o Optional[T]) IsSome() bool { return o.ok }
+
+// Get follows the comma-ok convention.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// OrElse returns the value or the given fallback.
+func (o Optional[T]) OrElse(fallback T) T {
+	if o.ok {
+		return o.value
+	}
+	return fallback
+}
+
+func (o Optional[T]) String() string {
+	if !o.ok {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%v)", o.value)
+}
+
+// MapOptional is a function, not a method: methods cannot introduce U.
+func MapOptional[T, U any](o Optional[T], f func(T) U) Optional[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return Some(f(o.value))
+}
+
+// Find returns the first element matching pred.
+func Find[T any](xs []T, pred func(T) bool) Optional[T] {
+	for _, x := range xs {
+		if pred(x) {
+			return Some(x)
+		}
+	}
+	return None[T]()
+}
@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestOptional(t *testing.T) {
+	some := Some(5)
+	if v, ok := some.Get(); !ok || v != 5 {
+		t.Errorf("Some.Get = %v, %v", v, ok)
+	}
+	none := None[int]()
+	if none.IsSome() {
+		t.Error("None reports IsSome")
+	}
+	if got := none.OrElse(7); got != 7 {
+		t.Errorf("OrElse = %d, want 7", got)
+	}
+	if some.String() != "Some(5)" || none.String() != "None" {
+		t.Errorf("String = %q, %q", some, none)
+	}
+}
+
+func TestMapOptionalAndFind(t *testing.T) {
+	got := MapOptional(Find([]int{1, 4, 9}, func(n int) bool { return n > 3 }), func(n int) int { return n * 10 })
+	if v, ok := got.Get(); !ok || v != 40 {
+		t.Errorf("MapOptional(Find) = %v", got)
+	}
+	if MapOptional(None[int](), func(n int) string { return "x" }).IsSome() {
+		t.Error("MapOptional on None produced a value")
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import "fmt"
+
+// Pair holds two values of possibly different types.
+type Pair[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// MakePair lets the compiler infer K and V from the arguments.
+func MakePair[K, V any](k K, v V) Pair[K, V] {
+	return Pair[K, V]{Key: k, Value: v}
+}
+
+func (p Pair[K, V]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.Key, p.Value)
+}
+
+// Swap returns a new pair with the types flipped. Methods cannot declare
+// their own type parameters, but they can use the receiver's in new orders.
+func (p Pair[K, V]) Swap() Pair[V, K] {
+	return Pair[V, K]{Key: p.Value, Value: p.Key}
+}
+
+// Zip combines two slices into pairs, stopping at the shorter one.
+func Zip[K, V any](ks []K, vs []V) []Pair[K, V] {
+	n := min(len(ks), len(vs))
+	out := make([]Pair[K, V], n)
+	for i := range n {
+		out[i] = Pair[K, V]{Key: ks[i], Value: vs[i]}
+	}
+	return out
+}
+
+// Entries turns a map into pairs. Map order is random, so callers that need
+// a stable order should sort the result.
+func Entries[K comparable, V any](m map[K]V) []Pair[K, V] {
+	out := make([]Pair[K, V], 0, len(m))
+	for k, v := range m {
+		out = append(out, Pair[K, V]{Key: k, Value: v})
+	}
+	return out
+}
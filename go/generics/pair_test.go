@@ -0,0 +1,38 @@
+package main
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestPair(t *testing.T) {
+	p := MakePair("a", 1)
+	if p.String() != "(a, 1)" {
+		t.Errorf("String = %q", p.String())
+	}
+	s := p.Swap()
+	if s.Key != 1 || s.Value != "a" {
+		t.Errorf("Swap = %v", s)
+	}
+}
+
+func TestZip(t *testing.T) {
+	got := Zip([]string{"a", "b", "c"}, []int{1, 2})
+	want := []Pair[string, int]{{"a", 1}, {"b", 2}}
+	if len(got) != len(want) {
+		t.Fatalf("Zip len = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Zip[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestEntries(t *testing.T) {
+	got := Entries(map[string]int{"b": 2, "a": 1})
+	sort.Slice(got, func(i, j int) bool { return got[i].Key < got[j].Key })
+	if len(got) != 2 || got[0] != (Pair[string, int]{"a", 1}) || got[1] != (Pair[string, int]{"b", 2}) {
+		t.Errorf("Entries = %v", got)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import "fmt"
+
+// Pitfall 1: inference only looks at function arguments. A type parameter
+// used only in the result must be given explicitly: Zero[int]().
+func Zero[T any]() T {
+	var zero T
+	return zero
+}
+
+// Pitfall 2: an interface type satisfies comparable, but == on two
+// interfaces holding uncomparable dynamic values (slices, maps, funcs)
+// panics at run time. The compiler cannot catch it.
+func Equal[T comparable](a, b T) bool {
+	return a == b
+}
+
+// SafeEqual reports whether Equal panicked instead of crashing.
+func SafeEqual[T comparable](a, b T) (eq bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("comparison panicked: %v", r)
+		}
+	}()
+	return Equal(a, b), nil
+}
+
+// Pitfall 3: a nil slice and an empty slice are different values, and the
+// generic code has to pick one. Filter above returns nil when nothing
+// matches; callers that JSON-encode the result will see null, not [].
+func NonNil[T any](xs []T) []T {
+	if xs == nil {
+		return []T{}
+	}
+	return xs
+}
+
+// Pitfall 4: a []Celsius cannot be passed where a []float64 is expected,
+// even though Celsius's underlying type is float64. A ~float64 constraint
+// accepts both without a copy-and-convert loop at the call site.
+func SumAsFloat[T ~float64](xs []T) float64 {
+	var total float64
+	for _, x := range xs {
+		total += float64(x)
+	}
+	return total
+}
+
+// Pitfall 5: a parameter of type []E turns a named slice type such as
+// Readings back into a plain []Celsius. Declaring S ~[]E keeps the
+// caller's named type (and its methods) on the result.
+type Readings []Celsius
+
+func Clone[S ~[]E, E any](s S) S {
+	if s == nil {
+		return nil
+	}
+	return append(S{}, s...)
+}
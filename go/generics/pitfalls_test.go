@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestZero(t *testing.T) {
+	if Zero[int]() != 0 || Zero[string]() != "" || Zero[*int]() != nil {
+		t.Error("Zero returned a non-zero value")
+	}
+}
+
+func TestSafeEqual(t *testing.T) {
+	if eq, err := SafeEqual[any](1, 1); err != nil || !eq {
+		t.Errorf("SafeEqual(1, 1) = %v, %v", eq, err)
+	}
+	if _, err := SafeEqual[any]([]int{1}, []int{1}); err == nil {
+		t.Error("SafeEqual on slices: expected an error from the recovered panic")
+	}
+}
+
+func TestNonNil(t *testing.T) {
+	if NonNil[int](nil) == nil {
+		t.Error("NonNil(nil) returned nil")
+	}
+}
+
+func TestClonePreservesType(t *testing.T) {
+	r := Readings{1, 2}
+	c := Clone(r)
+	c[0] = 99
+	if r[0] != 1 {
+		t.Error("Clone shares the backing array")
+	}
+	if SumAsFloat(c) != 101 {
+		t.Errorf("SumAsFloat = %v", SumAsFloat(c))
+	}
+	if Clone[Readings](nil) != nil {
+		t.Error("Clone(nil) should stay nil")
+	}
+}
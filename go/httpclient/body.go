@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxBodySize caps how much of a response is read into memory.
+const maxBodySize = 1 << 20
+
+// StatusError is returned for non-2xx responses. It keeps a short prefix
+// of the body because APIs usually explain the failure there.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// closeBody drains what is left of the body before closing it. A
+// connection only goes back to the pool if its body was read to EOF.
+func closeBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodySize))
+	_ = resp.Body.Close()
+}
+
+// decodeJSON checks the status, decodes at most maxBodySize bytes into v,
+// and always closes the body.
+func decodeJSON(resp *http.Response, v any) error {
+	defer closeBody(resp)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(snippet)}
+	}
+	if v == nil {
+		return nil
+	}
+
+	// Read one byte past the limit so an oversized body is reported
+	// instead of silently truncated into a confusing JSON error.
+	limited := io.LimitReader(resp.Body, maxBodySize+1)
+	data, err := io.ReadAll(limited)
+	if err != nil {
+		return fmt.Errorf("read body: %w", err)
+	}
+	if len(data) > maxBodySize {
+		return errors.New("response body exceeds limit")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode body: %w", err)
+	}
+	return nil
+}
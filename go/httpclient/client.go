@@ -0,0 +1,58 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// Config holds the knobs worth setting on every client. The zero value of
+// http.Client has no timeout at all, so a stalled server hangs the caller
+// forever.
+type Config struct {
+	// Timeout bounds the whole exchange, including reading the body.
+	Timeout time.Duration
+	// DialTimeout bounds establishing the TCP connection.
+	DialTimeout time.Duration
+	// TLSHandshakeTimeout bounds the TLS handshake.
+	TLSHandshakeTimeout time.Duration
+	// ResponseHeaderTimeout bounds waiting for headers after the request is sent.
+	ResponseHeaderTimeout time.Duration
+	// MaxIdleConnsPerHost controls how many keep-alive connections are kept
+	// per host. The default of 2 is low for a client that calls one API a lot.
+	MaxIdleConnsPerHost int
+}
+
+// DefaultConfig returns conservative settings for calling a JSON API.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:               10 * time.Second,
+		DialTimeout:           3 * time.Second,
+		TLSHandshakeTimeout:   3 * time.Second,
+		ResponseHeaderTimeout: 5 * time.Second,
+		MaxIdleConnsPerHost:   16,
+	}
+}
+
+// NewHTTPClient builds a client from cfg. Create it once and share it:
+// the transport owns the connection pool, so a new client per request
+// throws away keep-alive connections and leaks idle sockets.
+func NewHTTPClient(cfg Config) *http.Client {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   cfg.DialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		ForceAttemptHTTP2:     true,
+	}
+	return &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: transport,
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/XianingY/learn/go/httpclient
+
+go 1.22
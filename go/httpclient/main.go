@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+)
+
+func main() {
+	baseURL := flag.String("base-url", DefaultBaseURL, "API base URL")
+	userID := flag.Int("user", 1, "user whose todos to list")
+	timeout := flag.Duration("timeout", 5*time.Second, "overall deadline for the demo")
+	flag.Parse()
+
+	client := NewTodoClient(*baseURL, NewHTTPClient(DefaultConfig()), DefaultRetryPolicy())
+
+	// One deadline for everything below; each request inherits it.
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	todo, err := client.Get(ctx, 1)
+	if err != nil {
+		log.Fatalf("get todo: %v", err)
+	}
+	fmt.Printf("todo #%d: %q (done=%v)\n", todo.ID, todo.Title, todo.Completed)
+
+	todos, err := client.ListByUser(ctx, *userID)
+	if err != nil {
+		log.Fatalf("list todos: %v", err)
+	}
+	done := 0
+	for _, t := range todos {
+		if t.Completed {
+			done++
+		}
+	}
+	fmt.Printf("user %d: %d todos, %d completed\n", *userID, len(todos), done)
+
+	created, err := client.Create(ctx, Todo{UserID: *userID, Title: "learn http clients"})
+	if err != nil {
+		log.Fatalf("create todo: %v", err)
+	}
+	fmt.Printf("created todo #%d\n", created.ID)
+}
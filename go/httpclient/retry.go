@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes when and how long to wait between attempts.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy retries up to three times starting at 200ms.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 2 * time.Second}
+}
+
+// backoff returns the delay before the given retry (1-based) using
+// exponential growth with full jitter, so many clients retrying at once
+// do not stampede the server in lockstep.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.BaseDelay << (retry - 1)
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// retryable reports whether a status code is worth retrying.
+func retryable(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header given in seconds.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	secs, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	return time.Duration(secs) * time.Second, true
+}
+
+// Do sends the request built by newReq, retrying network errors and
+// retryable statuses. newReq is called for every attempt because a request
+// body can only be read once. Only use this for idempotent requests.
+func Do(ctx context.Context, client *http.Client, policy RetryPolicy, newReq func(context.Context) (*http.Request, error)) (*http.Response, error) {
+	attempts := max(policy.MaxAttempts, 1)
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		req, err := newReq(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+
+		var wait time.Duration
+		switch {
+		case err != nil:
+			// The caller's context ending is final, not a transient failure.
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = err
+			wait = policy.backoff(attempt)
+		case retryable(resp.StatusCode):
+			lastErr = &StatusError{StatusCode: resp.StatusCode}
+			var ok bool
+			if wait, ok = retryAfter(resp); !ok {
+				wait = policy.backoff(attempt)
+			}
+			if attempt == attempts {
+				// Hand the final response to the caller so it can inspect it.
+				return resp, nil
+			}
+			closeBody(resp)
+			// MaxDelay bounds every wait. Retrying sooner than the server
+			// asked would only earn another rejection, so give up instead.
+			if policy.MaxDelay > 0 && wait > policy.MaxDelay {
+				return nil, fmt.Errorf("server asked to wait %v, longer than max delay %v: %w", wait, policy.MaxDelay, lastErr)
+			}
+		default:
+			return resp, nil
+		}
+
+		if attempt == attempts {
+			break
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil, fmt.Errorf("giving up after %d attempts: %w", attempts, lastErr)
+}
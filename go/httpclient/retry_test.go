@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastPolicy(attempts int) RetryPolicy {
+	return RetryPolicy{MaxAttempts: attempts, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+}
+
+func getter(url string) func(context.Context) (*http.Request, error) {
+	return func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	}
+}
+
+func TestDoRetriesThenSucceeds(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	resp, err := Do(context.Background(), srv.Client(), fastPolicy(5), getter(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeBody(resp)
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Errorf("status %d after %d calls, want 200 after 3", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestDoReturnsLastRetryableResponse(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	resp, err := Do(context.Background(), srv.Client(), fastPolicy(2), getter(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeBody(resp)
+	if resp.StatusCode != http.StatusBadGateway || calls.Load() != 2 {
+		t.Errorf("status %d after %d calls", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestDoDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	resp, err := Do(context.Background(), srv.Client(), fastPolicy(3), getter(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeBody(resp)
+	if calls.Load() != 1 {
+		t.Errorf("404 was retried: %d calls", calls.Load())
+	}
+}
+
+func TestDoHonorsContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	// MaxDelay allows the 10s Retry-After, so the deadline is what ends it.
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Minute}
+	start := time.Now()
+	_, err := Do(ctx, srv.Client(), policy, getter(srv.URL))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want DeadlineExceeded", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Do waited out Retry-After instead of the context deadline")
+	}
+}
+
+func TestDoGivesUpOnLongRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	// No deadline on the context: only MaxDelay can stop the wait.
+	start := time.Now()
+	_, err := Do(context.Background(), srv.Client(), fastPolicy(3), getter(srv.URL))
+	var se *StatusError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("err = %v, want a wrapped 503 StatusError", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("server called %d times, want 1", calls.Load())
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Do waited for Retry-After instead of giving up")
+	}
+}
+
+func TestDoHonorsShortRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	resp, err := Do(context.Background(), srv.Client(), fastPolicy(3), getter(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeBody(resp)
+	if resp.StatusCode != http.StatusOK || calls.Load() != 2 {
+		t.Errorf("status %d after %d calls, want 200 after 2", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestDoNetworkError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	_, err := Do(context.Background(), http.DefaultClient, fastPolicy(2), getter(url))
+	if err == nil {
+		t.Fatal("expected an error from a closed server")
+	}
+}
+
+func TestBackoffBounds(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	for retry := 1; retry <= 10; retry++ {
+		if d := p.backoff(retry); d < 0 || d > p.MaxDelay {
+			t.Errorf("backoff(%d) = %v, outside [0, %v]", retry, d, p.MaxDelay)
+		}
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.Timeout = 50 * time.Millisecond
+	_, err := NewHTTPClient(cfg).Get(srv.URL)
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+}
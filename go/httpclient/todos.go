@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultBaseURL is the public JSONPlaceholder fake REST API.
+const DefaultBaseURL = "https://jsonplaceholder.typicode.com"
+
+// Todo mirrors one item from the /todos endpoint.
+type Todo struct {
+	ID        int    `json:"id,omitempty"`
+	UserID    int    `json:"userId"`
+	Title     string `json:"title"`
+	Completed bool   `json:"completed"`
+}
+
+// TodoClient is a small typed wrapper: callers deal in Todo values and
+// errors, never in URLs, status codes, or bodies.
+type TodoClient struct {
+	baseURL string
+	http    *http.Client
+	retry   RetryPolicy
+}
+
+// NewTodoClient builds a client for the API at baseURL.
+func NewTodoClient(baseURL string, client *http.Client, retry RetryPolicy) *TodoClient {
+	return &TodoClient{baseURL: baseURL, http: client, retry: retry}
+}
+
+// Get fetches a single todo by ID.
+func (c *TodoClient) Get(ctx context.Context, id int) (Todo, error) {
+	var t Todo
+	err := c.getJSON(ctx, "/todos/"+strconv.Itoa(id), nil, &t)
+	return t, err
+}
+
+// ListByUser fetches every todo that belongs to a user.
+func (c *TodoClient) ListByUser(ctx context.Context, userID int) ([]Todo, error) {
+	var ts []Todo
+	q := url.Values{"userId": {strconv.Itoa(userID)}}
+	err := c.getJSON(ctx, "/todos", q, &ts)
+	return ts, err
+}
+
+// Create posts a new todo and returns the server's copy. POST is not
+// idempotent, so it is sent once and never retried.
+func (c *TodoClient) Create(ctx context.Context, t Todo) (Todo, error) {
+	body, err := json.Marshal(t)
+	if err != nil {
+		return Todo{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/todos", bytes.NewReader(body))
+	if err != nil {
+		return Todo{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return Todo{}, fmt.Errorf("create todo: %w", err)
+	}
+	var created Todo
+	if err := decodeJSON(resp, &created); err != nil {
+		return Todo{}, fmt.Errorf("create todo: %w", err)
+	}
+	return created, nil
+}
+
+func (c *TodoClient) getJSON(ctx context.Context, path string, query url.Values, v any) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	resp, err := Do(ctx, c.http, c.retry, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return fmt.Errorf("GET %s: %w", path, err)
+	}
+	if err := decodeJSON(resp, v); err != nil {
+		return fmt.Errorf("GET %s: %w", path, err)
+	}
+	return nil
+}
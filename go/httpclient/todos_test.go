@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestAPI(t *testing.T) *TodoClient {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /todos/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "1" {
+			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":1,"userId":2,"title":"write tests","completed":true}`))
+	})
+	mux.HandleFunc("GET /todos", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("userId") != "2" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`[{"id":1,"userId":2,"title":"a"},{"id":2,"userId":2,"title":"b","completed":true}]`))
+	})
+	mux.HandleFunc("POST /todos", func(w http.ResponseWriter, r *http.Request) {
+		var in Todo
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		in.ID = 201
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(in)
+	})
+	mux.HandleFunc("GET /huge", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`"` + strings.Repeat("x", maxBodySize) + `"`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return NewTodoClient(srv.URL, srv.Client(), fastPolicy(2))
+}
+
+func TestTodoClientGet(t *testing.T) {
+	c := newTestAPI(t)
+	got, err := c.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Todo{ID: 1, UserID: 2, Title: "write tests", Completed: true}
+	if got != want {
+		t.Errorf("Get = %+v, want %+v", got, want)
+	}
+}
+
+func TestTodoClientGetNotFound(t *testing.T) {
+	c := newTestAPI(t)
+	_, err := c.Get(context.Background(), 99)
+	var se *StatusError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusNotFound {
+		t.Fatalf("err = %v, want a 404 StatusError", err)
+	}
+	if !strings.Contains(se.Body, "not found") {
+		t.Errorf("StatusError body = %q", se.Body)
+	}
+}
+
+func TestTodoClientListByUser(t *testing.T) {
+	c := newTestAPI(t)
+	todos, err := c.ListByUser(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(todos) != 2 || !todos[1].Completed {
+		t.Errorf("ListByUser = %+v", todos)
+	}
+}
+
+func TestTodoClientCreate(t *testing.T) {
+	c := newTestAPI(t)
+	got, err := c.Create(context.Background(), Todo{UserID: 5, Title: "new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 201 || got.Title != "new" || got.UserID != 5 {
+		t.Errorf("Create = %+v", got)
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	c := newTestAPI(t)
+	var s string
+	err := c.getJSON(context.Background(), "/huge", nil, &s)
+	if err == nil || !strings.Contains(err.Error(), "exceeds limit") {
+		t.Errorf("err = %v, want a size limit error", err)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func main() {
+	file := flag.String("file", "", "JSON array of orders to stream (default: generate one)")
+	count := flag.Int("count", 50000, "number of orders to generate when -file is empty")
+	flag.Parse()
+
+	fmt.Println("== encoding ==")
+	o := sampleOrder(1)
+	out, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(out))
+
+	fmt.Println("\n== strict vs lenient decoding ==")
+	typo := `{"id":"A-1","status":"paid","custmer":{"name":"Ann"}}`
+	if _, err := DecodeStrict(strings.NewReader(typo)); err != nil {
+		fmt.Println("strict:", err)
+	}
+	if _, unknown, err := DecodeLenient([]byte(typo)); err == nil {
+		fmt.Println("lenient: unknown fields", unknown)
+	}
+
+	fmt.Println("\n== streaming ==")
+	path := *file
+	if path == "" {
+		dir, err := os.MkdirTemp("", "orders")
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		path = filepath.Join(dir, "orders.json")
+		if err := generate(path, *count); err != nil {
+			log.Fatal(err)
+		}
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	byStatus := map[Status]int{}
+	var revenue Money
+	start := time.Now()
+	n, err := StreamOrders(f, func(o Order) error {
+		byStatus[o.Status]++
+		if o.Status != StatusCancelled {
+			revenue += o.Total
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("streamed %d orders (%d bytes) in %v\n", n, info.Size(), time.Since(start).Round(time.Millisecond))
+	fmt.Println("revenue:", revenue)
+	counts, _ := json.Marshal(byStatus)
+	fmt.Println("by status:", string(counts))
+}
+
+func generate(path string, count int) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteOrders(f, count, sampleOrder); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func sampleOrder(i int) Order {
+	placed := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Minute)
+	o := Order{
+		ID:     fmt.Sprintf("A-%d", i),
+		Status: Status(i % 4),
+		Customer: Customer{
+			ID:      i % 100,
+			Name:    fmt.Sprintf("Customer %d", i%100),
+			Address: Address{Street: "1 Main St", City: "Springfield", Country: "US"},
+		},
+		Items: []Item{
+			{SKU: "BK-1", Name: "Book", Quantity: 1 + i%3, UnitPrice: 1299},
+			{SKU: "PN-2", Name: "Pen", Quantity: 2, UnitPrice: 150},
+		},
+		PlacedAt: placed,
+	}
+	if o.Status == StatusShipped {
+		shipped := placed.Add(48 * time.Hour)
+		o.ShippedAt = &shipped
+	}
+	o.Total = o.ComputeTotal()
+	return o
+}
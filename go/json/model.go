@@ -0,0 +1,68 @@
+package main
+
+import "time"
+
+// Order is the top-level document. Field tags control the JSON names;
+// anything without a tag uses the Go field name as-is.
+type Order struct {
+	ID       string    `json:"id"`
+	Status   Status    `json:"status"`
+	Customer Customer  `json:"customer"`
+	Items    []Item    `json:"items"`
+	Total    Money     `json:"total"`
+	PlacedAt time.Time `json:"placed_at"`
+
+	// ShippedAt is a pointer so "not shipped yet" (nil, omitted) is
+	// distinguishable from the zero time. omitempty never omits a
+	// non-pointer struct such as time.Time.
+	ShippedAt *time.Time `json:"shipped_at,omitempty"`
+
+	// Notes disappears from the output when empty.
+	Notes string `json:"notes,omitempty"`
+
+	// Tags is omitted when nil or empty; an explicit [] in the input still
+	// decodes to an empty, non-nil slice.
+	Tags []string `json:"tags,omitempty"`
+
+	// Metadata keeps arbitrary extra data as-is for later decoding.
+	Metadata map[string]any `json:"metadata,omitempty"`
+
+	// internalScore is unexported and therefore never encoded.
+	internalScore int
+
+	// AuditTrail is exported but explicitly excluded with "-".
+	AuditTrail []string `json:"-"`
+}
+
+// Customer shows an embedded struct: Address's fields are promoted into
+// the customer object rather than nested under "Address".
+type Customer struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	Address
+}
+
+type Address struct {
+	Street  string `json:"street"`
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+// Item uses ",string" on Quantity: the number is encoded as a JSON string,
+// which some APIs insist on.
+type Item struct {
+	SKU       string `json:"sku"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity,string"`
+	UnitPrice Money  `json:"unit_price"`
+}
+
+// ComputeTotal sums the line items.
+func (o *Order) ComputeTotal() Money {
+	var total Money
+	for _, it := range o.Items {
+		total += it.UnitPrice * Money(it.Quantity)
+	}
+	return total
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatusText(t *testing.T) {
+	out, err := json.Marshal(map[Status]int{StatusPaid: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{"paid":2}` {
+		t.Errorf("Marshal map = %s", out)
+	}
+	var s Status
+	if err := json.Unmarshal([]byte(`"shipped"`), &s); err != nil || s != StatusShipped {
+		t.Errorf("Unmarshal = %v, %v", s, err)
+	}
+	if err := json.Unmarshal([]byte(`"lost"`), &s); err == nil {
+		t.Error("Unmarshal unknown status: expected an error")
+	}
+	if _, err := json.Marshal(Status(42)); err == nil {
+		t.Error("Marshal unknown status: expected an error")
+	}
+}
+
+func TestOrderEncoding(t *testing.T) {
+	o := Order{
+		ID:         "A-1",
+		Status:     StatusPending,
+		Customer:   Customer{ID: 1, Name: "Ann", Address: Address{City: "Oslo"}},
+		Items:      []Item{{SKU: "X", Quantity: 3, UnitPrice: 250}},
+		PlacedAt:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		AuditTrail: []string{"created"},
+	}
+	o.Total = o.ComputeTotal()
+
+	out, err := json.Marshal(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(out)
+
+	for _, want := range []string{
+		`"total":"7.50"`,
+		`"quantity":"3"`,
+		`"city":"Oslo"`,
+		`"status":"pending"`,
+		`"placed_at":"2024-01-02T03:04:05Z"`,
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("output missing %s: %s", want, s)
+		}
+	}
+	for _, absent := range []string{"shipped_at", "notes", "tags", "metadata", "AuditTrail", "internalScore", "Address", "email"} {
+		if strings.Contains(s, absent) {
+			t.Errorf("output should not contain %q: %s", absent, s)
+		}
+	}
+}
+
+func TestOrderRoundTrip(t *testing.T) {
+	in := sampleOrder(2) // StatusShipped, so ShippedAt is set
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out Order
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.ID != in.ID || out.Total != in.Total || out.Status != in.Status || len(out.Items) != len(in.Items) {
+		t.Errorf("round trip mismatch: %+v vs %+v", out, in)
+	}
+	if out.ShippedAt == nil || !out.ShippedAt.Equal(*in.ShippedAt) {
+		t.Errorf("ShippedAt = %v, want %v", out.ShippedAt, in.ShippedAt)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in cents. Floats are the wrong type for currency, so
+// the JSON form is a decimal string like "12.34" that is parsed exactly.
+type Money int64
+
+func (m Money) String() string {
+	// Work on the magnitude as a uint64: negating math.MinInt64 as an
+	// int64 overflows back to itself.
+	sign, mag := "", uint64(m)
+	if m < 0 {
+		sign, mag = "-", -uint64(m)
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, mag/100, mag%100)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts "12.34", "12.3",
+// "12", and a bare JSON number for lenient input. By convention null is a
+// no-op that leaves the value unchanged, as it is for built-in types.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("money: expected string or number, got %s", data)
+		}
+		s = n.String()
+	}
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// ParseMoney parses a decimal string with an optional leading minus and
+// at most two fractional digits. strconv.ParseInt alone would also accept
+// signs inside each part, so "1.-5" or "--5" are rejected up front.
+func ParseMoney(s string) (Money, error) {
+	orig := s
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if !isDigits(whole) || (hasFrac && (!isDigits(frac) || len(frac) > 2)) {
+		return 0, fmt.Errorf("money: invalid amount %q", orig)
+	}
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("money: invalid amount %q: %w", orig, err)
+	}
+	var cents int64
+	if hasFrac {
+		if len(frac) == 1 {
+			frac += "0"
+		}
+		cents, err = strconv.ParseInt(frac, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("money: invalid amount %q: %w", orig, err)
+		}
+	}
+	// units*100 + cents must fit in an int64. ParseInt only catches
+	// inputs that overflow before the multiplication.
+	if units > (math.MaxInt64-cents)/100 {
+		return 0, fmt.Errorf("money: amount %q out of range", orig)
+	}
+	v := Money(units*100 + cents)
+	if neg {
+		v = -v
+	}
+	return v, nil
+}
+
+// isDigits reports whether s is non-empty and made only of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
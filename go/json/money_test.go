@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{"12.34", 1234, false},
+		{"12.3", 1230, false},
+		{"12", 1200, false},
+		{"-0.05", -5, false},
+		{"1.234", 0, true},
+		{"1.", 0, true},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"1.-5", 0, true},
+		{"1.+5", 0, true},
+		{"--5", 0, true},
+		{"+3", 0, true},
+		{"-", 0, true},
+		{".5", 0, true},
+		{"99999999999999999999", 0, true},
+		{"100000000000000000", 0, true},
+		{"92233720368547758.99", 0, true},
+		{"9223372036854775807", 0, true},
+		{"-100000000000000000", 0, true},
+		{"92233720368547758.07", 92233720368547758_07, false},
+		{"-92233720368547758.07", -92233720368547758_07, false},
+		{"10000000000000000", 10000000000000000_00, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMoney(%q) = %v, %v; want %v, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	out, err := json.Marshal(Money(-1205))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `"-12.05"` {
+		t.Errorf("Marshal = %s", out)
+	}
+
+	for in, want := range map[string]Money{`"3.10"`: 310, `3.1`: 310, `7`: 700} {
+		var m Money
+		if err := json.Unmarshal([]byte(in), &m); err != nil || m != want {
+			t.Errorf("Unmarshal(%s) = %v, %v; want %v", in, m, err, want)
+		}
+	}
+
+	// null leaves the existing value alone.
+	m := Money(123)
+	if err := json.Unmarshal([]byte(`null`), &m); err != nil || m != 123 {
+		t.Errorf("Unmarshal(null) = %v, %v; want 1.23 unchanged", m, err)
+	}
+	var o struct {
+		Total Money `json:"total"`
+	}
+	if err := json.Unmarshal([]byte(`{"total":null}`), &o); err != nil || o.Total != 0 {
+		t.Errorf(`Unmarshal({"total":null}) = %v, %v`, o.Total, err)
+	}
+
+	if err := json.Unmarshal([]byte(`true`), &m); err == nil {
+		t.Error("Unmarshal(true): expected an error")
+	}
+}
+
+func TestMoneyStringExtremes(t *testing.T) {
+	tests := map[Money]string{
+		math.MaxInt64: "92233720368547758.07",
+		math.MinInt64: "-92233720368547758.08",
+		-1:            "-0.01",
+		0:             "0.00",
+	}
+	for m, want := range tests {
+		if got := m.String(); got != want {
+			t.Errorf("Money(%d).String() = %q, want %q", int64(m), got, want)
+		}
+	}
+}
+
+func TestParseMoneyErrorQuotesInput(t *testing.T) {
+	_, err := ParseMoney("-1.234")
+	if err == nil || !strings.Contains(err.Error(), `"-1.234"`) {
+		t.Errorf("err = %v, want it to quote the original input", err)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import "fmt"
+
+// Status is stored as an int but appears in JSON as a string. Implementing
+// encoding.TextMarshaler (rather than json.Marshaler) is enough, and it
+// also makes Status usable as a map key in JSON objects.
+type Status int
+
+const (
+	StatusPending Status = iota
+	StatusPaid
+	StatusShipped
+	StatusCancelled
+)
+
+var statusNames = map[Status]string{
+	StatusPending:   "pending",
+	StatusPaid:      "paid",
+	StatusShipped:   "shipped",
+	StatusCancelled: "cancelled",
+}
+
+func (s Status) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Status) MarshalText() ([]byte, error) {
+	name, ok := statusNames[s]
+	if !ok {
+		return nil, fmt.Errorf("status: unknown value %d", int(s))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Status) UnmarshalText(text []byte) error {
+	for k, name := range statusNames {
+		if name == string(text) {
+			*s = k
+			return nil
+		}
+	}
+	return fmt.Errorf("status: unknown name %q", text)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StreamOrders decodes a top-level JSON array of orders one element at a
+// time, calling fn for each. Memory use stays flat no matter how large the
+// array is, unlike json.Unmarshal into a []Order.
+func StreamOrders(r io.Reader, fn func(Order) error) (int, error) {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, fmt.Errorf("read opening token: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return 0, fmt.Errorf("expected '[' at start of input, got %v", tok)
+	}
+
+	n := 0
+	for dec.More() {
+		var o Order
+		if err := dec.Decode(&o); err != nil {
+			return n, fmt.Errorf("decode order %d: %w", n, err)
+		}
+		if err := fn(o); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return n, fmt.Errorf("read closing token: %w", err)
+	}
+	return n, nil
+}
+
+// StreamNDJSON decodes newline-delimited JSON: one order per line, no
+// surrounding array. A Decoder handles this without any line splitting.
+func StreamNDJSON(r io.Reader, fn func(Order) error) (int, error) {
+	dec := json.NewDecoder(r)
+	n := 0
+	for {
+		var o Order
+		err := dec.Decode(&o)
+		if errors.Is(err, io.EOF) {
+			return n, nil
+		}
+		if err != nil {
+			return n, fmt.Errorf("decode order %d: %w", n, err)
+		}
+		if err := fn(o); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// WriteOrders encodes orders as a JSON array without building the whole
+// document in memory first.
+func WriteOrders(w io.Writer, count int, gen func(i int) Order) error {
+	if _, err := io.WriteString(w, "[\n"); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		// Encode appends a newline after each value, which keeps the
+		// generated file readable.
+		if err := enc.Encode(gen(i)); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]\n")
+	return err
+}
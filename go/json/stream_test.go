@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestStreamOrdersFile(t *testing.T) {
+	f, err := os.Open("testdata/orders.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var ids []string
+	n, err := StreamOrders(f, func(o Order) error {
+		if o.Total != o.ComputeTotal() {
+			t.Errorf("order %s: total %v, items sum to %v", o.ID, o.Total, o.ComputeTotal())
+		}
+		ids = append(ids, o.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || strings.Join(ids, ",") != "A-100,A-101,A-102" {
+		t.Errorf("streamed %d orders: %v", n, ids)
+	}
+}
+
+func TestStreamOrdersRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteOrders(&buf, 100, sampleOrder); err != nil {
+		t.Fatal(err)
+	}
+	n, err := StreamOrders(&buf, func(Order) error { return nil })
+	if err != nil || n != 100 {
+		t.Errorf("StreamOrders = %d, %v; want 100, nil", n, err)
+	}
+}
+
+func TestStreamOrdersErrors(t *testing.T) {
+	if _, err := StreamOrders(strings.NewReader(`{"id":"x"}`), func(Order) error { return nil }); err == nil {
+		t.Error("expected an error for a non-array document")
+	}
+	if n, err := StreamOrders(strings.NewReader(`[{"id":"a"},{"id":`), func(Order) error { return nil }); err == nil || n != 1 {
+		t.Errorf("truncated input: n=%d err=%v", n, err)
+	}
+	stop := errors.New("stop")
+	if _, err := StreamOrders(strings.NewReader(`[{"id":"a"},{"id":"b"}]`), func(Order) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("callback error not propagated: %v", err)
+	}
+}
+
+func TestStreamNDJSON(t *testing.T) {
+	in := `{"id":"a","status":"paid"}
+{"id":"b","status":"pending"}
+`
+	var ids []string
+	n, err := StreamNDJSON(strings.NewReader(in), func(o Order) error {
+		ids = append(ids, o.ID)
+		return nil
+	})
+	if err != nil || n != 2 || strings.Join(ids, ",") != "a,b" {
+		t.Errorf("StreamNDJSON = %d, %v, %v", n, ids, err)
+	}
+}
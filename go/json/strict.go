@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DecodeStrict decodes exactly one order and fails on fields the struct
+// does not know about, which catches typos like "custmer" that the default
+// decoder would silently ignore. Trailing data after the object is also an
+// error.
+func DecodeStrict(r io.Reader) (Order, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var o Order
+	if err := dec.Decode(&o); err != nil {
+		return Order{}, fmt.Errorf("decode order: %w", err)
+	}
+	// dec.More reports false before a stray '}' or ']', so ask for the next
+	// token instead: anything but a clean EOF is trailing data.
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return Order{}, errors.New("decode order: unexpected data after the object")
+	}
+	return o, nil
+}
+
+// DecodeLenient decodes the known fields and returns the names of any top
+// level fields it did not recognise, so callers can log them instead of
+// failing.
+func DecodeLenient(data []byte) (Order, []string, error) {
+	var o Order
+	if err := json.Unmarshal(data, &o); err != nil {
+		return Order{}, nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Order{}, nil, err
+	}
+	known := map[string]bool{
+		"id": true, "status": true, "customer": true, "items": true,
+		"total": true, "placed_at": true, "shipped_at": true, "notes": true,
+		"tags": true, "metadata": true,
+	}
+	var unknown []string
+	for k := range raw {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	return o, unknown, nil
+}
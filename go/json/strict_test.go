@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeStrict(t *testing.T) {
+	if _, err := DecodeStrict(strings.NewReader("{\"id\":\"a\",\"status\":\"paid\"}\n")); err != nil {
+		t.Errorf("valid input: %v", err)
+	}
+	tests := map[string]string{
+		"unknown field":    `{"id":"a","custmer":{}}`,
+		"unknown nested":   `{"id":"a","customer":{"nmae":"x"}}`,
+		"trailing data":    `{"id":"a"} {"id":"b"}`,
+		"trailing brace":   `{"id":"x"}}`,
+		"trailing bracket": `{"id":"x"}]`,
+		"trailing garbage": `{"id":"x"} x`,
+		"bad status":       `{"status":"lost"}`,
+	}
+	for name, in := range tests {
+		if _, err := DecodeStrict(strings.NewReader(in)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestDecodeLenient(t *testing.T) {
+	o, unknown, err := DecodeLenient([]byte(`{"id":"a","extra":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.ID != "a" || len(unknown) != 1 || unknown[0] != "extra" {
+		t.Errorf("DecodeLenient = %+v, %v", o, unknown)
+	}
+}
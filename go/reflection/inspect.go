@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DescribeType returns a multi-line summary of t: its kind, and for
+// structs the fields with their tags, plus the method set.
+func DescribeType(t reflect.Type) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (kind %s)\n", t, t.Kind())
+
+	// Methods declared on *T are not in T's method set, so look at both.
+	// Method.Type includes the receiver as the first parameter.
+	for _, mt := range []reflect.Type{t, reflect.PointerTo(t)} {
+		for i := 0; i < mt.NumMethod(); i++ {
+			m := mt.Method(i)
+			fmt.Fprintf(&b, "  method on %s: %s%s\n", mt, m.Name, strings.TrimPrefix(m.Type.String(), "func"))
+		}
+	}
+
+	if t.Kind() != reflect.Struct {
+		return b.String()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fmt.Fprintf(&b, "  field %s %s", f.Name, f.Type)
+		if f.Tag != "" {
+			fmt.Fprintf(&b, " `%s`", f.Tag)
+		}
+		if !f.IsExported() {
+			b.WriteString(" (unexported)")
+		}
+		if f.Anonymous {
+			b.WriteString(" (embedded)")
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// SetField sets a named field on the struct that ptr points to. It shows
+// the two rules that trip people up: the value must be addressable (so a
+// pointer has to be passed in), and the field must be exported.
+func SetField(ptr any, name string, value any) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("SetField: need a non-nil pointer, got %T", ptr)
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("SetField: %s is not a struct", v.Type())
+	}
+	sf, ok := v.Type().FieldByName(name)
+	if !ok {
+		return fmt.Errorf("SetField: no field %q in %s", name, v.Type())
+	}
+	// Value.FieldByName panics if the field is promoted through a nil
+	// embedded pointer; FieldByIndexErr reports it instead.
+	f, err := v.FieldByIndexErr(sf.Index)
+	if err != nil {
+		return fmt.Errorf("SetField: field %q: %w", name, err)
+	}
+	if !f.CanSet() {
+		return fmt.Errorf("SetField: field %q cannot be set (unexported)", name)
+	}
+	nv := reflect.ValueOf(value)
+	if !nv.IsValid() {
+		f.SetZero()
+		return nil
+	}
+	// Convert would accept more (int to string gives "\x07"), so only
+	// plain assignability is allowed, as in ordinary Go code.
+	if !nv.Type().AssignableTo(f.Type()) {
+		return fmt.Errorf("SetField: cannot use %s as %s", nv.Type(), f.Type())
+	}
+	f.Set(nv)
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDescribeType(t *testing.T) {
+	got := DescribeType(reflect.TypeOf(User{}))
+	for _, want := range []string{
+		"main.User (kind struct)",
+		"method on main.User: DisplayName",
+		"method on *main.User: Rename",
+		"field ID int `map:\"id\"`",
+		"field Audit main.Audit (embedded)",
+		"field loginCount int (unexported)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("DescribeType missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "method on main.User: Rename") {
+		t.Error("Rename has a pointer receiver and is not in User's method set")
+	}
+
+	if got := DescribeType(reflect.TypeOf(3)); got != "int (kind int)\n" {
+		t.Errorf("DescribeType(int) = %q", got)
+	}
+}
+
+func TestSetField(t *testing.T) {
+	u := User{ID: 1, Home: &Address{}}
+	if err := SetField(&u, "Name", "bo"); err != nil || u.Name != "bo" {
+		t.Errorf("SetField Name: %v, %q", err, u.Name)
+	}
+	if err := SetField(&u, "Home", nil); err != nil || u.Home != nil {
+		t.Errorf("SetField nil: %v, %v", err, u.Home)
+	}
+	// Promoted fields from the embedded struct are found by name too.
+	if err := SetField(&u, "CreatedBy", "me"); err != nil || u.CreatedBy != "me" {
+		t.Errorf("SetField promoted: %v, %q", err, u.CreatedBy)
+	}
+
+	errs := map[string]error{
+		"not a pointer": SetField(u, "Name", "x"),
+		"nil pointer":   SetField((*User)(nil), "Name", "x"),
+		"not a struct":  SetField(new(int), "Name", "x"),
+		"missing field": SetField(&u, "Nope", "x"),
+		"unexported":    SetField(&u, "loginCount", 1),
+		"wrong type":    SetField(&u, "ID", "seven"),
+		"int to string": SetField(&u, "Name", 7),
+		"nil embedded":  SetField(&struct{ *Audit }{}, "CreatedBy", "x"),
+	}
+	for name, err := range errs {
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
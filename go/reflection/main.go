@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+type Address struct {
+	City    string `map:"city"`
+	Country string `map:"country,omitempty"`
+}
+
+type Audit struct {
+	CreatedBy string `map:"created_by"`
+}
+
+type User struct {
+	ID       int               `map:"id"`
+	Name     string            `map:"name"`
+	Email    string            `map:"email,omitempty"`
+	Password string            `map:"-"`
+	Home     *Address          `map:"home,omitempty"`
+	Tags     []string          `map:"tags"`
+	Prefs    map[string]string `map:"prefs,omitempty"`
+	Audit
+	loginCount int
+}
+
+func (u User) DisplayName() string { return fmt.Sprintf("%s (#%d)", u.Name, u.ID) }
+
+func (u *User) Rename(name string) { u.Name = name }
+
+// Node is self-referential so Pretty has a cycle to detect.
+type Node struct {
+	Value int
+	Next  *Node
+}
+
+func main() {
+	u := User{
+		ID:       7,
+		Name:     "ada",
+		Password: "hunter2",
+		Home:     &Address{City: "London"},
+		Tags:     []string{"admin"},
+		Audit:    Audit{CreatedBy: "system"},
+	}
+
+	fmt.Println("== type inspection ==")
+	fmt.Print(DescribeType(reflect.TypeOf(u)))
+
+	fmt.Println("\n== setting fields ==")
+	if err := SetField(&u, "Name", "Ada Lovelace"); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("after SetField:", u.Name)
+	fmt.Println(SetField(u, "Name", "x"))
+	fmt.Println(SetField(&u, "loginCount", 3))
+	fmt.Println(SetField(&u, "ID", "seven"))
+
+	fmt.Println("\n== pretty printer ==")
+	fmt.Println(Pretty(u))
+	a := &Node{Value: 1}
+	a.Next = &Node{Value: 2, Next: a}
+	fmt.Println(Pretty(a))
+	if _, err := ToMap(a); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println("\n== struct to map ==")
+	m, err := ToMap(u)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Printf("%s = %v\n", k, m[k])
+	}
+}
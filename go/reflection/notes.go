@@ -0,0 +1,33 @@
+package main
+
+// When reflection is the wrong tool
+//
+// Reflection trades compile-time checks for run-time flexibility. The code
+// above is worth it because it has to handle types it has never seen. Most
+// code does not, and there reflection costs more than it gives:
+//
+//   - Errors move to run time. A typo in a field name passed to SetField
+//     compiles fine and fails in production; a plain assignment would not
+//     compile at all.
+//
+//   - It is slow. Every reflect call allocates or type-checks at run time.
+//     Pretty and ToMap are fine for debugging output, not for a hot loop.
+//     See BenchmarkToMap vs BenchmarkManualMap in tomap_test.go.
+//
+//   - It is hard to read. A type switch or an interface method says what
+//     happens for each type; reflect code makes the reader simulate it.
+//
+// Prefer, in order:
+//
+//  1. Concrete code. If there are three types, write three functions.
+//  2. Interfaces. If types need to describe themselves, give them a method
+//     (fmt.Stringer, json.Marshaler) and let each type decide.
+//  3. Type switches, when the set of types is known and small.
+//  4. Generics, when the logic is the same for every type and only the
+//     type changes. They are checked at compile time.
+//  5. Code generation (go generate, stringer), when the logic depends on
+//     the shape of a type but the types are known at build time.
+//
+// Reach for reflect when the shape of the data is only known at run time:
+// encoders and decoders, ORMs, dependency injection containers, test
+// helpers that compare arbitrary values, and debug printers like Pretty.
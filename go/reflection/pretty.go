@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Pretty renders any value as indented, Go-like text. Unlike %+v it
+// follows pointers, sorts map keys, and marks cycles through pointers,
+// maps, and slices instead of looping.
+func Pretty(v any) string {
+	p := printer{seen: map[visit]bool{}}
+	p.print(reflect.ValueOf(v), 0)
+	return p.b.String()
+}
+
+// visit identifies a pointer, map, or slice currently being traversed.
+// The type is part of the key because a struct and its first field share
+// an address, and the length because s and s[:1] share a data pointer.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+func visitOf(v reflect.Value) visit {
+	k := visit{ptr: v.Pointer(), typ: v.Type()}
+	if v.Kind() == reflect.Slice {
+		k.len = v.Len()
+	}
+	return k
+}
+
+type printer struct {
+	b    strings.Builder
+	seen map[visit]bool
+}
+
+// enter marks v as being printed, or writes a cycle marker and returns
+// false if it already is. Seeing a value again while still inside it
+// means the structure refers back to itself.
+func (p *printer) enter(v reflect.Value) bool {
+	k := visitOf(v)
+	if p.seen[k] {
+		fmt.Fprintf(&p.b, "<cycle %s>", v.Type())
+		return false
+	}
+	p.seen[k] = true
+	return true
+}
+
+func (p *printer) leave(v reflect.Value) {
+	delete(p.seen, visitOf(v))
+}
+
+func (p *printer) indent(depth int) {
+	p.b.WriteString(strings.Repeat("  ", depth))
+}
+
+func (p *printer) print(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		p.b.WriteString("nil")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			p.b.WriteString("nil")
+			return
+		}
+		if !p.enter(v) {
+			return
+		}
+		defer p.leave(v)
+		p.b.WriteByte('&')
+		p.print(v.Elem(), depth)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			p.b.WriteString("nil")
+			return
+		}
+		p.print(v.Elem(), depth)
+
+	case reflect.Struct:
+		fmt.Fprintf(&p.b, "%s{", v.Type())
+		if v.NumField() == 0 {
+			p.b.WriteByte('}')
+			return
+		}
+		p.b.WriteByte('\n')
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			p.indent(depth + 1)
+			fmt.Fprintf(&p.b, "%s: ", t.Field(i).Name)
+			// Unexported fields can be read through reflect, just not
+			// passed to Interface() or set.
+			p.print(v.Field(i), depth+1)
+			p.b.WriteString(",\n")
+		}
+		p.indent(depth)
+		p.b.WriteByte('}')
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			p.b.WriteString("nil")
+			return
+		}
+		if v.Len() == 0 {
+			fmt.Fprintf(&p.b, "%s{}", v.Type())
+			return
+		}
+		if v.Kind() == reflect.Slice {
+			if !p.enter(v) {
+				return
+			}
+			defer p.leave(v)
+		}
+		fmt.Fprintf(&p.b, "%s{\n", v.Type())
+		for i := 0; i < v.Len(); i++ {
+			p.indent(depth + 1)
+			p.print(v.Index(i), depth+1)
+			p.b.WriteString(",\n")
+		}
+		p.indent(depth)
+		p.b.WriteByte('}')
+
+	case reflect.Map:
+		if v.IsNil() {
+			p.b.WriteString("nil")
+			return
+		}
+		if v.Len() == 0 {
+			fmt.Fprintf(&p.b, "%s{}", v.Type())
+			return
+		}
+		if !p.enter(v) {
+			return
+		}
+		defer p.leave(v)
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		fmt.Fprintf(&p.b, "%s{\n", v.Type())
+		for _, k := range keys {
+			p.indent(depth + 1)
+			p.print(k, depth+1)
+			p.b.WriteString(": ")
+			p.print(v.MapIndex(k), depth+1)
+			p.b.WriteString(",\n")
+		}
+		p.indent(depth)
+		p.b.WriteByte('}')
+
+	case reflect.String:
+		fmt.Fprintf(&p.b, "%q", v.String())
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if v.IsNil() {
+			p.b.WriteString("nil")
+			return
+		}
+		fmt.Fprintf(&p.b, "%s(%#x)", v.Type(), v.Pointer())
+
+	default:
+		// Bools, numbers, and complex values. fmt accepts a reflect.Value
+		// and prints the value it holds, even for unexported fields.
+		fmt.Fprintf(&p.b, "%v", v)
+	}
+}
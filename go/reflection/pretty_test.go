@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrettyScalars(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{nil, "nil"},
+		{42, "42"},
+		{"hi", `"hi"`},
+		{true, "true"},
+		{[]int(nil), "nil"},
+		{[]int{}, "[]int{}"},
+		{map[string]int{}, "map[string]int{}"},
+		{(*int)(nil), "nil"},
+		{struct{}{}, "struct {}{}"},
+	}
+	for _, tt := range tests {
+		if got := Pretty(tt.in); got != tt.want {
+			t.Errorf("Pretty(%#v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPrettyNested(t *testing.T) {
+	got := Pretty(map[string]any{"b": []int{1}, "a": &Address{City: "Oslo"}})
+	want := `map[string]interface {}{
+  "a": &main.Address{
+    City: "Oslo",
+    Country: "",
+  },
+  "b": []int{
+    1,
+  },
+}`
+	if got != want {
+		t.Errorf("Pretty =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPrettyCycle(t *testing.T) {
+	n := &Node{Value: 1}
+	n.Next = n
+	got := Pretty(n)
+	if !strings.Contains(got, "<cycle *main.Node>") {
+		t.Errorf("cycle not detected:\n%s", got)
+	}
+}
+
+func TestPrettySharedPointerIsNotACycle(t *testing.T) {
+	shared := &Address{City: "Rome"}
+	got := Pretty([]*Address{shared, shared})
+	if strings.Contains(got, "cycle") || strings.Count(got, "Rome") != 2 {
+		t.Errorf("shared pointer printed wrongly:\n%s", got)
+	}
+}
+
+func TestPrettyUnexported(t *testing.T) {
+	got := Pretty(User{loginCount: 5})
+	if !strings.Contains(got, "loginCount: 5") {
+		t.Errorf("unexported field missing:\n%s", got)
+	}
+}
+
+func TestPrettyMapAndSliceCycles(t *testing.T) {
+	m := map[string]any{}
+	m["self"] = m
+	if got := Pretty(m); !strings.Contains(got, "<cycle map[string]interface {}>") {
+		t.Errorf("map cycle not detected:\n%s", got)
+	}
+
+	s := []any{1, nil}
+	s[1] = s
+	if got := Pretty(s); !strings.Contains(got, "<cycle []interface {}>") {
+		t.Errorf("slice cycle not detected:\n%s", got)
+	}
+
+	// The same map twice side by side is not a cycle.
+	shared := map[string]int{"a": 1}
+	if got := Pretty([]map[string]int{shared, shared}); strings.Contains(got, "cycle") {
+		t.Errorf("shared map reported as a cycle:\n%s", got)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+// tagName is the struct tag key this module reads, e.g. `map:"id"`.
+const tagName = "map"
+
+// tagOptions is a parsed tag in the common "name,opt1,opt2" format used
+// by encoding/json and friends.
+type tagOptions struct {
+	Name      string
+	OmitEmpty bool
+	Skip      bool
+	Inline    bool
+}
+
+// parseTag reads the map tag of a struct field. A missing or empty name
+// falls back to the Go field name. "-" skips the field entirely.
+func parseTag(f reflect.StructField) tagOptions {
+	tag, ok := f.Tag.Lookup(tagName)
+	if ok && tag == "-" {
+		return tagOptions{Skip: true}
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	to := tagOptions{Name: name}
+	if to.Name == "" {
+		to.Name = f.Name
+	}
+	for _, opt := range strings.Split(opts, ",") {
+		switch opt {
+		case "omitempty":
+			to.OmitEmpty = true
+		case "inline":
+			to.Inline = true
+		}
+	}
+	return to
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrCycle is returned by ToMap when a value refers back to itself.
+var ErrCycle = errors.New("ToMap: cycle detected")
+
+// ToMap converts a struct (or pointer to one) into a map keyed by the map
+// tag of each exported field. Nested structs become nested maps, embedded
+// structs and fields tagged ",inline" are flattened into the parent, and
+// ",omitempty" drops zero values. Opaque structs such as time.Time are
+// kept as they are (see opaque), and cycles are reported as ErrCycle.
+func ToMap(v any) (map[string]any, error) {
+	c := converter{seen: map[visit]bool{}}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("ToMap: nil %s", rv.Type())
+		}
+		if !c.enter(rv) {
+			return nil, fmt.Errorf("%w at %s", ErrCycle, rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ToMap: expected a struct, got %s", rv.Kind())
+	}
+	out := make(map[string]any)
+	if err := c.structToMap(rv, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// converter carries the set of pointers, maps, and slices on the current
+// path, the same bookkeeping Pretty uses to find cycles.
+type converter struct {
+	seen map[visit]bool
+}
+
+func (c *converter) enter(v reflect.Value) bool {
+	k := visitOf(v)
+	if c.seen[k] {
+		return false
+	}
+	c.seen[k] = true
+	return true
+}
+
+func (c *converter) leave(v reflect.Value) {
+	delete(c.seen, visitOf(v))
+}
+
+func (c *converter) structToMap(rv reflect.Value, out map[string]any) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		// An unexported embedded struct can still promote exported
+		// fields, so it is only skipped if it turns out not to be
+		// inlined below.
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		opts := parseTag(f)
+		if opts.Skip {
+			continue
+		}
+		fv := rv.Field(i)
+		if opts.OmitEmpty && fv.IsZero() {
+			continue
+		}
+
+		if inner := indirect(fv); (f.Anonymous || opts.Inline) && isPlainStruct(inner) {
+			if err := c.inline(fv, out); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		v, err := c.convert(fv)
+		if err != nil {
+			return err
+		}
+		out[opts.Name] = v
+	}
+	return nil
+}
+
+// inline flattens fv, a struct or a chain of pointers to one, into out.
+func (c *converter) inline(fv reflect.Value, out map[string]any) error {
+	if fv.Kind() != reflect.Pointer {
+		return c.structToMap(fv, out)
+	}
+	if fv.IsNil() {
+		return nil
+	}
+	if !c.enter(fv) {
+		return fmt.Errorf("%w at %s", ErrCycle, fv.Type())
+	}
+	defer c.leave(fv)
+	return c.inline(fv.Elem(), out)
+}
+
+// convert turns nested structs (and slices/maps of them) into maps, and
+// returns everything else as its plain Go value.
+func (c *converter) convert(v reflect.Value) (any, error) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Kind() == reflect.Pointer {
+			if !c.enter(v) {
+				return nil, fmt.Errorf("%w at %s", ErrCycle, v.Type())
+			}
+			defer c.leave(v)
+		}
+		return c.convert(v.Elem())
+	case reflect.Struct:
+		if !isPlainStruct(v) {
+			return v.Interface(), nil
+		}
+		m := make(map[string]any)
+		if err := c.structToMap(v, m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		if !containsStruct(v.Type().Elem()) {
+			return v.Interface(), nil
+		}
+		if v.Kind() == reflect.Slice && v.Len() > 0 {
+			if !c.enter(v) {
+				return nil, fmt.Errorf("%w at %s", ErrCycle, v.Type())
+			}
+			defer c.leave(v)
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			e, err := c.convert(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = e
+		}
+		return out, nil
+	case reflect.Map:
+		if v.IsNil() || !containsStruct(v.Type().Elem()) {
+			return v.Interface(), nil
+		}
+		if !c.enter(v) {
+			return nil, fmt.Errorf("%w at %s", ErrCycle, v.Type())
+		}
+		defer c.leave(v)
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			e, err := c.convert(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			out[fmt.Sprint(iter.Key())] = e
+		}
+		return out, nil
+	default:
+		return v.Interface(), nil
+	}
+}
+
+var (
+	stringerType      = reflect.TypeFor[fmt.Stringer]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// isPlainStruct reports whether v is a struct that should be taken apart
+// field by field. Opaque structs are left whole: those with no exported
+// fields (time.Time would become an empty map) and those that already
+// know how to present themselves via String or MarshalText.
+func isPlainStruct(v reflect.Value) bool {
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return false
+	}
+	t := v.Type()
+	for _, it := range []reflect.Type{stringerType, textMarshalerType} {
+		if t.Implements(it) || reflect.PointerTo(t).Implements(it) {
+			return false
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// indirect follows pointers, returning an invalid Value for nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func containsStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct || t.Kind() == reflect.Interface
+}
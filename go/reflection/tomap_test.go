@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestToMap(t *testing.T) {
+	u := User{
+		ID:       1,
+		Name:     "ada",
+		Password: "secret",
+		Home:     &Address{City: "London", Country: "UK"},
+		Tags:     []string{"a"},
+		Audit:    Audit{CreatedBy: "sys"},
+	}
+	got, err := ToMap(&u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"id":         1,
+		"name":       "ada",
+		"home":       map[string]any{"city": "London", "country": "UK"},
+		"tags":       []string{"a"},
+		"created_by": "sys",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestToMapOptions(t *testing.T) {
+	type Inner struct {
+		X int `map:"x"`
+	}
+	type Outer struct {
+		Plain   string
+		In      Inner   `map:",inline"`
+		Items   []Inner `map:"items"`
+		Lookup  map[string]*Inner
+		Nothing *Inner `map:"nothing"`
+		private int
+	}
+	got, err := ToMap(Outer{
+		Plain:  "p",
+		In:     Inner{X: 1},
+		Items:  []Inner{{X: 2}},
+		Lookup: map[string]*Inner{"k": {X: 3}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"Plain":   "p",
+		"x":       1,
+		"items":   []any{map[string]any{"x": 2}},
+		"Lookup":  map[string]any{"k": map[string]any{"x": 3}},
+		"nothing": nil,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+type inner struct {
+	X int `map:"x"`
+	y int
+}
+
+func TestToMapUnexportedEmbedded(t *testing.T) {
+	// Like encoding/json, exported fields promoted from an unexported
+	// embedded struct are kept; the unexported fields are not.
+	got, err := ToMap(struct {
+		inner
+		Y int
+	}{inner{X: 1, y: 9}, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]any{"x": 1, "Y": 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap = %#v, want %#v", got, want)
+	}
+
+	got, err = ToMap(struct {
+		*inner
+		Y int
+	}{&inner{X: 3}, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]any{"x": 3, "Y": 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap pointer = %#v, want %#v", got, want)
+	}
+
+	// An unexported embedded non-struct has nothing to promote.
+	got, err = ToMap(struct {
+		level
+		Y int
+	}{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]any{"Y": 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap non-struct = %#v, want %#v", got, want)
+	}
+}
+
+func TestToMapErrors(t *testing.T) {
+	if _, err := ToMap(3); err == nil {
+		t.Error("ToMap(int): expected an error")
+	}
+	if _, err := ToMap((*User)(nil)); err == nil {
+		t.Error("ToMap(nil pointer): expected an error")
+	}
+}
+
+func TestToMapCycles(t *testing.T) {
+	a := &Node{Value: 1}
+	a.Next = &Node{Value: 2, Next: a}
+	if _, err := ToMap(a); !errors.Is(err, ErrCycle) {
+		t.Errorf("pointer cycle: err = %v, want ErrCycle", err)
+	}
+
+	type Holder struct {
+		Items []any
+		Index map[string]any
+	}
+	items := []any{nil}
+	items[0] = items
+	if _, err := ToMap(Holder{Items: items}); !errors.Is(err, ErrCycle) {
+		t.Errorf("slice cycle: err = %v, want ErrCycle", err)
+	}
+	index := map[string]any{}
+	index["self"] = index
+	if _, err := ToMap(Holder{Index: index}); !errors.Is(err, ErrCycle) {
+		t.Errorf("map cycle: err = %v, want ErrCycle", err)
+	}
+
+	type Loop struct {
+		*Loop
+		N int
+	}
+	l := &Loop{N: 1}
+	l.Loop = l
+	if _, err := ToMap(l); !errors.Is(err, ErrCycle) {
+		t.Errorf("embedded cycle: err = %v, want ErrCycle", err)
+	}
+
+	// A pointer reached twice without looping is fine.
+	home := &Address{City: "Oslo"}
+	type Two struct{ A, B *Address }
+	if _, err := ToMap(Two{A: home, B: home}); err != nil {
+		t.Errorf("shared pointer: %v", err)
+	}
+}
+
+type level int
+
+func (l level) String() string { return fmt.Sprintf("L%d", int(l)) }
+
+type version struct{ Major, Minor int }
+
+func (v version) String() string { return fmt.Sprintf("v%d.%d", v.Major, v.Minor) }
+
+func TestToMapKeepsOpaqueStructs(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	type Event struct {
+		At      time.Time  `map:"at"`
+		Seen    *time.Time `map:"seen"`
+		Version version    `map:"version"`
+		Level   level      `map:"level"`
+	}
+	got, err := ToMap(Event{At: at, Seen: &at, Version: version{1, 2}, Level: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"at": at, "seen": at, "version": version{1, 2}, "level": level(3)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+// The benchmarks below back up the claim in notes.go: hand-written code
+// is much faster than the reflective version.
+
+func BenchmarkToMap(b *testing.B) {
+	u := User{ID: 1, Name: "ada", Home: &Address{City: "London"}, Tags: []string{"a"}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ToMap(u); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkManualMap(b *testing.B) {
+	u := User{ID: 1, Name: "ada", Home: &Address{City: "London"}, Tags: []string{"a"}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m := map[string]any{"id": u.ID, "name": u.Name, "tags": u.Tags, "created_by": u.CreatedBy}
+		if u.Email != "" {
+			m["email"] = u.Email
+		}
+		if u.Home != nil {
+			m["home"] = map[string]any{"city": u.Home.City}
+		}
+		_ = m
+	}
+}
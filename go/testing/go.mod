@@ -0,0 +1,3 @@
+module github.com/XianingY/learn/go/testing
+
+go 1.22
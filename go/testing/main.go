@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/XianingY/learn/go/testing/textfmt"
+)
+
+func main() {
+	fmt.Println(textfmt.Truncate("The quick brown fox jumps over the lazy dog", 20))
+
+	fmt.Printf("[%s]\n", textfmt.Pad("left", 10, textfmt.Left))
+	fmt.Printf("[%s]\n", textfmt.Pad("right", 10, textfmt.Right))
+	fmt.Printf("[%s]\n", textfmt.Pad("center", 10, textfmt.Center))
+
+	fmt.Println()
+	for _, line := range textfmt.Wrap("Table-driven tests, subtests, examples, benchmarks, fuzzing, and golden files all live next to this code.", 32) {
+		fmt.Println(line)
+	}
+
+	fmt.Println()
+	fmt.Print(textfmt.NewTable("language", "year", "typing").
+		AddRow("Go", "2009", "static").
+		AddRow("Rust", "2010", "static").
+		AddRow("Python", "1991", "dynamic"))
+}
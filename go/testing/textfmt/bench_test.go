@@ -0,0 +1,44 @@
+package textfmt
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// sink stops the compiler from optimising away benchmarked calls.
+var sink any
+
+func BenchmarkTruncate(b *testing.B) {
+	s := strings.Repeat("héllo ", 100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sink = Truncate(s, 40)
+	}
+}
+
+// Sub-benchmarks show how cost scales with input size.
+func BenchmarkWrap(b *testing.B) {
+	for _, words := range []int{10, 100, 1000} {
+		text := strings.Repeat("lorem ipsum ", words/2)
+		b.Run(fmt.Sprintf("words=%d", words), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(text)))
+			for i := 0; i < b.N; i++ {
+				sink = Wrap(text, 40)
+			}
+		})
+	}
+}
+
+func BenchmarkTable(b *testing.B) {
+	t := NewTable("a", "b", "c")
+	for i := 0; i < 50; i++ {
+		t.AddRow("row", "12345", "some longer text")
+	}
+	// Setup above is excluded from the measurement.
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sink = t.String()
+	}
+}
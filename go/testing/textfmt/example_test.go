@@ -0,0 +1,45 @@
+package textfmt_test
+
+import (
+	"fmt"
+
+	"github.com/XianingY/learn/go/testing/textfmt"
+)
+
+// Examples are compiled and run by go test, and the output is compared
+// with the // Output: comment. They double as documentation in go doc.
+
+func ExampleTruncate() {
+	fmt.Println(textfmt.Truncate("Hello, world", 8))
+	// Output: Hello, …
+}
+
+func ExamplePad() {
+	fmt.Printf("[%s]\n", textfmt.Pad("go", 6, textfmt.Right))
+	fmt.Printf("[%s]\n", textfmt.Pad("go", 6, textfmt.Center))
+	// Output:
+	// [    go]
+	// [  go  ]
+}
+
+func ExampleWrap() {
+	for _, line := range textfmt.Wrap("testable examples are checked by go test", 16) {
+		fmt.Println(line)
+	}
+	// Output:
+	// testable
+	// examples are
+	// checked by go
+	// test
+}
+
+func ExampleTable() {
+	fmt.Print(textfmt.NewTable("name", "score").AddRow("ann", "9").AddRow("bo", "10"))
+	// Output:
+	// +------+-------+
+	// | name | score |
+	// +------+-------+
+	// | ann  |     9 |
+	// | bo   |    10 |
+	// +------+-------+
+}
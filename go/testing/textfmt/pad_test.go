@@ -0,0 +1,56 @@
+package textfmt
+
+import (
+	"fmt"
+	"testing"
+	"unicode/utf8"
+)
+
+// Subtests group related checks under one parent so they can be run
+// selectively, e.g. go test -run 'TestPad/center' -v.
+func TestPad(t *testing.T) {
+	t.Run("left", func(t *testing.T) {
+		t.Parallel()
+		if got := Pad("ab", 5, Left); got != "ab   " {
+			t.Errorf("got %q", got)
+		}
+	})
+	t.Run("right", func(t *testing.T) {
+		t.Parallel()
+		if got := Pad("ab", 5, Right); got != "   ab" {
+			t.Errorf("got %q", got)
+		}
+	})
+	t.Run("center", func(t *testing.T) {
+		t.Parallel()
+		// The odd space goes on the right.
+		if got := Pad("ab", 5, Center); got != " ab  " {
+			t.Errorf("got %q", got)
+		}
+	})
+	t.Run("too wide", func(t *testing.T) {
+		t.Parallel()
+		if got := Pad("abcdef", 3, Right); got != "abcdef" {
+			t.Errorf("got %q", got)
+		}
+	})
+}
+
+// Parallel subtests over every alignment and input. Since Go 1.22 each
+// iteration gets its own align and in, so capturing them in a parallel
+// closure is safe. Names like "2/\"héllo\"" keep each subtest selectable.
+func TestPadWidth(t *testing.T) {
+	t.Parallel()
+	inputs := []string{"", "a", "héllo", "日本"}
+	for _, align := range []Align{Left, Right, Center} {
+		for _, in := range inputs {
+			t.Run(fmt.Sprintf("%d/%q", align, in), func(t *testing.T) {
+				t.Parallel()
+				got := Pad(in, 8, align)
+				if n := utf8.RuneCountInString(got); n != 8 {
+					t.Errorf("Pad(%q, 8, %d) has %d runes: %q", in, align, n, got)
+				}
+			})
+		}
+	}
+}
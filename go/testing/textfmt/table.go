@@ -0,0 +1,93 @@
+package textfmt
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Table renders rows as an ASCII table. The first row is the header.
+// Columns are as wide as their widest cell, and a column is right aligned
+// when every non-empty, non-header cell in it looks like a number.
+type Table struct {
+	rows [][]string
+}
+
+// NewTable starts a table with the given header.
+func NewTable(header ...string) *Table {
+	return &Table{rows: [][]string{header}}
+}
+
+// AddRow appends a row. Short rows are padded with empty cells and extra
+// cells are dropped.
+func (t *Table) AddRow(cells ...string) *Table {
+	row := make([]string, len(t.rows[0]))
+	copy(row, cells)
+	t.rows = append(t.rows, row)
+	return t
+}
+
+// String renders the table.
+func (t *Table) String() string {
+	ncol := len(t.rows[0])
+	widths := make([]int, ncol)
+	numeric := make([]bool, ncol)
+	for c := range numeric {
+		numeric[c] = len(t.rows) > 1
+	}
+	for r, row := range t.rows {
+		for c, cell := range row {
+			widths[c] = max(widths[c], utf8.RuneCountInString(cell))
+			if r > 0 && cell != "" && !isNumber(cell) {
+				numeric[c] = false
+			}
+		}
+	}
+
+	var b strings.Builder
+	sep := func() {
+		b.WriteByte('+')
+		for _, w := range widths {
+			b.WriteString(strings.Repeat("-", w+2))
+			b.WriteByte('+')
+		}
+		b.WriteByte('\n')
+	}
+
+	sep()
+	for r, row := range t.rows {
+		b.WriteByte('|')
+		for c, cell := range row {
+			align := Left
+			if r == 0 {
+				align = Center
+			} else if numeric[c] {
+				align = Right
+			}
+			b.WriteByte(' ')
+			b.WriteString(Pad(cell, widths[c], align))
+			b.WriteString(" |")
+		}
+		b.WriteByte('\n')
+		if r == 0 {
+			sep()
+		}
+	}
+	sep()
+	return b.String()
+}
+
+func isNumber(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	dot, digit := false, false
+	for _, r := range s {
+		switch {
+		case r == '.' && !dot:
+			dot = true
+		case r >= '0' && r <= '9':
+			digit = true
+		default:
+			return false
+		}
+	}
+	return digit
+}
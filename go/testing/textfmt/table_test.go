@@ -0,0 +1,67 @@
+package textfmt
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// Golden files keep large expected outputs out of the test source. When
+// the output changes on purpose, run with -update and review the diff.
+func TestTable(t *testing.T) {
+	tests := []struct {
+		name  string
+		table *Table
+	}{
+		{
+			name: "languages",
+			table: NewTable("language", "year", "typing").
+				AddRow("Go", "2009", "static").
+				AddRow("Rust", "2010", "static").
+				AddRow("Python", "1991", "dynamic"),
+		},
+		{
+			name: "numbers",
+			table: NewTable("item", "qty", "price").
+				AddRow("apple", "3", "0.5").
+				AddRow("watermelon", "1", "-12.25").
+				AddRow("fig", "120"),
+		},
+		{
+			name:  "header only",
+			table: NewTable("a", "b"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.table.String()
+			golden := filepath.Join("testdata", filepath.Base(t.Name())+".golden")
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if got != string(want) {
+				t.Errorf("output differs from %s:\ngot:\n%s\nwant:\n%s", golden, got, want)
+			}
+		})
+	}
+}
+
+func TestIsNumber(t *testing.T) {
+	for s, want := range map[string]bool{
+		"1": true, "-2.5": true, ".5": true, "3.": true,
+		"1.2.3": false, "": false, "-": false, "x1": false, ".": false, "-.": false,
+	} {
+		if got := isNumber(s); got != want {
+			t.Errorf("isNumber(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Package textfmt contains small helpers for laying out plain text in a
+// terminal. All widths are measured in runes, not bytes.
+package textfmt
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Ellipsis is appended by Truncate when it shortens a string.
+const Ellipsis = "…"
+
+// Truncate shortens s to at most width runes, replacing the tail with
+// Ellipsis when anything was cut.
+func Truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width == 1 {
+		return Ellipsis
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + Ellipsis
+}
+
+// Align selects where padding goes.
+type Align int
+
+const (
+	Left Align = iota
+	Right
+	Center
+)
+
+// Pad fills s with spaces up to width runes. Strings that are already wide
+// enough are returned unchanged. Center puts any odd space on the right.
+func Pad(s string, width int, align Align) string {
+	gap := width - utf8.RuneCountInString(s)
+	if gap <= 0 {
+		return s
+	}
+	switch align {
+	case Right:
+		return strings.Repeat(" ", gap) + s
+	case Center:
+		left := gap / 2
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", gap-left)
+	default:
+		return s + strings.Repeat(" ", gap)
+	}
+}
+
+// Wrap breaks text into lines of at most width runes, splitting on
+// whitespace. Words longer than width are split across lines. Runs of
+// whitespace collapse to a single space.
+func Wrap(text string, width int) []string {
+	if width <= 0 {
+		return nil
+	}
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(text) {
+		w := []rune(word)
+		for len(w) > width {
+			if len(line) > 0 {
+				lines = append(lines, string(line))
+				line = line[:0]
+			}
+			lines = append(lines, string(w[:width]))
+			w = w[width:]
+		}
+		if len(w) == 0 {
+			continue
+		}
+		switch {
+		case len(line) == 0:
+			line = append(line, w...)
+		case len(line)+1+len(w) <= width:
+			line = append(line, ' ')
+			line = append(line, w...)
+		default:
+			lines = append(lines, string(line))
+			line = append(line[:0], w...)
+		}
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
@@ -0,0 +1,31 @@
+package textfmt
+
+import "testing"
+
+// A table-driven test: each case is a row of data, and one loop runs them
+// all. Adding a case is one line, and a failure names the case that broke.
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		width int
+		want  string
+	}{
+		{"fits", "hello", 10, "hello"},
+		{"exact", "hello", 5, "hello"},
+		{"cut", "hello world", 8, "hello w…"},
+		{"width one", "hello", 1, "…"},
+		{"width zero", "hello", 0, ""},
+		{"negative width", "hello", -3, ""},
+		{"empty", "", 3, ""},
+		{"multibyte", "héllo wörld", 7, "héllo …"},
+		{"cjk", "日本語のテキスト", 4, "日本語…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.in, tt.width); got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+			}
+		})
+	}
+}
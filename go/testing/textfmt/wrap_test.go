@@ -0,0 +1,57 @@
+package textfmt
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  []string
+	}{
+		{"the quick brown fox", 10, []string{"the quick", "brown fox"}},
+		{"  spaced   out  ", 20, []string{"spaced out"}},
+		{"abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"a verylongword b", 4, []string{"a", "very", "long", "word", "b"}},
+		{"", 5, nil},
+		{"word", 0, nil},
+	}
+	for _, tt := range tests {
+		if got := Wrap(tt.in, tt.width); !slices.Equal(got, tt.want) {
+			t.Errorf("Wrap(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
+
+// FuzzWrap checks properties that must hold for any input instead of
+// specific outputs. The seeds run as part of a normal go test; go test
+// -fuzz FuzzWrap generates new inputs from them.
+func FuzzWrap(f *testing.F) {
+	f.Add("the quick brown fox", 10)
+	f.Add("a\tb\nc", 1)
+	f.Add("日本語のテキスト", 3)
+	f.Fuzz(func(t *testing.T, text string, width int) {
+		if !utf8.ValidString(text) || width < 1 || width > 200 {
+			t.Skip()
+		}
+		lines := Wrap(text, width)
+		for _, line := range lines {
+			if n := utf8.RuneCountInString(line); n > width {
+				t.Fatalf("line %q has %d runes, width %d", line, n, width)
+			}
+			if line == "" || strings.TrimSpace(line) != line {
+				t.Fatalf("line %q is empty or has surrounding space", line)
+			}
+		}
+		// No text is lost: the words, glued together, are unchanged.
+		want := strings.Join(strings.Fields(text), "")
+		got := strings.ReplaceAll(strings.Join(lines, ""), " ", "")
+		if got != want {
+			t.Fatalf("text changed: got %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five-field cron expression:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Each field accepts *, a number, a range (1-5), a list (1,3,5), and a
+// step (*/15 or 0-30/10). Day-of-week runs 0-6 with 0 as Sunday. The
+// shortcuts @hourly, @daily, @weekly, @monthly, and @yearly are accepted.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// As in classic cron, when both day fields are restricted a day
+	// matches if either one does. A field starting with "*" (including
+	// a step like "*/2") counts as unrestricted.
+	domStar, dowStar bool
+}
+
+var cronShortcuts = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+	"@yearly":  "0 0 1 1 *",
+}
+
+type fieldRange struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]fieldRange{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day-of-month", 1, 31},
+	{"month", 1, 12},
+	{"day-of-week", 0, 6},
+}
+
+// ParseSchedule parses a cron expression.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if s, ok := cronShortcuts[spec]; ok {
+		spec = s
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != 5 {
+		return Schedule{}, fmt.Errorf("cron: want 5 fields, got %d in %q", len(parts), spec)
+	}
+	var bits [5]uint64
+	for i, part := range parts {
+		b, err := parseField(part, cronFields[i])
+		if err != nil {
+			return Schedule{}, err
+		}
+		bits[i] = b
+	}
+	return Schedule{
+		minute: bits[0], hour: bits[1], dom: bits[2], month: bits[3], dow: bits[4],
+		domStar: strings.HasPrefix(parts[2], "*"), dowStar: strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+// parseField turns one field into a bit set with bit n set when value n
+// matches.
+func parseField(field string, r fieldRange) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		expr, stepStr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("cron: bad step %q in %s field", stepStr, r.name)
+			}
+			step = n
+		}
+
+		lo, hi := r.min, r.max
+		switch {
+		case expr == "*":
+		case strings.Contains(expr, "-"):
+			a, b, _ := strings.Cut(expr, "-")
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(a)
+			hi, err2 = strconv.Atoi(b)
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("cron: bad range %q in %s field", expr, r.name)
+			}
+		default:
+			n, err := strconv.Atoi(expr)
+			if err != nil {
+				return 0, fmt.Errorf("cron: bad value %q in %s field", expr, r.name)
+			}
+			lo, hi = n, n
+			// "5/10" means "from 5 to the end, every 10".
+			if hasStep {
+				hi = r.max
+			}
+		}
+		if lo < r.min || hi > r.max || lo > hi {
+			return 0, fmt.Errorf("cron: %q out of range %d-%d in %s field", expr, r.min, r.max, r.name)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func has(bits uint64, v int) bool { return bits&(1<<uint(v)) != 0 }
+
+func (s Schedule) dayMatches(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next returns the first matching minute strictly after t, in t's
+// location. It returns the zero Time if nothing matches within five years
+// (e.g. "0 0 30 2 *", February 30th).
+func (s Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc))
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// forward guards against wall-clock times skipped by a DST change:
+// time.Date maps 02:00 on a spring-forward day to 01:00, which would
+// stall the search. In that case step to the top of the next real hour,
+// so 01:30 EST moves to 03:00 EDT rather than 03:30.
+func forward(from, to time.Time) time.Time {
+	if to.After(from) {
+		return to
+	}
+	// Truncate works on absolute time, which is off by the half hour in
+	// zones like Asia/Kolkata; trim the local minutes instead.
+	n := from.Add(time.Hour)
+	return n.Add(-time.Duration(n.Minute())*time.Minute -
+		time.Duration(n.Second())*time.Second - time.Duration(n.Nanosecond()))
+}
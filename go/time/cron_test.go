@@ -0,0 +1,119 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 7",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"1-x * * * *",
+		"@often",
+	} {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("ParseSchedule(%q): expected an error", spec)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Saturday.
+	base := time.Date(2024, 3, 9, 14, 5, 30, 0, time.UTC)
+	at := func(mo time.Month, d, h, mi int) time.Time { return time.Date(2024, mo, d, h, mi, 0, 0, time.UTC) }
+
+	tests := []struct {
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"* * * * *", base, at(3, 9, 14, 6)},
+		{"*/15 * * * *", base, at(3, 9, 14, 15)},
+		{"5/20 * * * *", base, at(3, 9, 14, 25)},
+		{"0 * * * *", base, at(3, 9, 15, 0)},
+		{"@daily", base, at(3, 10, 0, 0)},
+		{"30 9 * * 1-5", base, at(3, 11, 9, 30)},
+		{"0 12 1,15 * *", base, at(3, 15, 12, 0)},
+		{"0 0 1 */3 *", base, at(4, 1, 0, 0)},
+		{"0 0 29 2 *", base, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 1st of the month OR a Monday.
+		{"0 0 1 * 1", base, at(3, 11, 0, 0)},
+		// A stepped "*" still counts as unrestricted, so both must match:
+		// the first Monday on day 1, 4, 7, ... (not Sunday the 10th).
+		{"0 0 */3 * 1", base, at(3, 25, 0, 0)},
+		// Strictly after: an exact match on the input moves on.
+		{"5 14 * * *", at(3, 9, 14, 5), at(3, 10, 14, 5)},
+		// Year rollover.
+		{"0 0 1 1 *", base, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		s, err := ParseSchedule(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseSchedule(%q): %v", tt.spec, err)
+		}
+		if got := s.Next(tt.from); !got.Equal(tt.want) {
+			t.Errorf("%q.Next(%v) = %v, want %v", tt.spec, tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestScheduleNextNever(t *testing.T) {
+	s, err := ParseSchedule("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Next(time.Now()); !got.IsZero() {
+		t.Errorf("February 30th fired at %v", got)
+	}
+}
+
+func TestScheduleNextKeepsLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := ParseSchedule("30 9 * * *")
+	got := s.Next(time.Date(2024, 3, 9, 12, 0, 0, 0, ny))
+	// 09:30 local on the day DST starts is 13:30 UTC, not 14:30.
+	if got.Location() != ny || got.Hour() != 9 || got.UTC().Hour() != 13 {
+		t.Errorf("Next = %v (UTC %v)", got, got.UTC())
+	}
+}
+
+func TestScheduleNextSkippedHour(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 02:30 does not exist on 2024-03-10 in New York; cron skips that day.
+	s, _ := ParseSchedule("30 2 * * *")
+	got := s.Next(time.Date(2024, 3, 9, 12, 0, 0, 0, ny))
+	want := time.Date(2024, 3, 11, 2, 30, 0, 0, ny)
+	if !got.Equal(want) {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+}
+
+func TestScheduleNextFromSkippedHour(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// From 01:30 EST the next hour is 03:00 EDT, which must not be
+	// jumped over on the way through the gap.
+	s, _ := ParseSchedule("0 3 * * *")
+	got := s.Next(time.Date(2024, 3, 10, 1, 30, 0, 0, ny))
+	want := time.Date(2024, 3, 10, 3, 0, 0, 0, ny)
+	if !got.Equal(want) {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+}
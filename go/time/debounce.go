@@ -0,0 +1,80 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// Debouncer runs fn once activity has stopped for wait. Each Trigger
+// pushes the deadline back, so a burst of calls produces one run at the
+// end. Typical use: reload a config after a flurry of file-change events.
+type Debouncer struct {
+	clock Clock
+	wait  time.Duration
+	fn    func()
+
+	mu    sync.Mutex
+	timer Timer
+}
+
+// NewDebouncer returns a Debouncer that calls fn on its own goroutine
+// (with RealClock) or synchronously inside Advance (with FakeClock).
+func NewDebouncer(c Clock, wait time.Duration, fn func()) *Debouncer {
+	return &Debouncer{clock: c, wait: wait, fn: fn}
+}
+
+// Trigger records activity and restarts the quiet period.
+func (d *Debouncer) Trigger() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.timer = d.clock.AfterFunc(d.wait, d.fn)
+}
+
+// Cancel drops a pending run, if any.
+func (d *Debouncer) Cancel() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+}
+
+// Throttler allows at most one action per interval, acting on the first
+// call and dropping the rest (leading edge). Typical use: limit how often
+// a progress line is redrawn.
+type Throttler struct {
+	clock    Clock
+	interval time.Duration
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+func NewThrottler(c Clock, interval time.Duration) *Throttler {
+	return &Throttler{clock: c, interval: interval}
+}
+
+// Allow reports whether the caller may act now.
+func (t *Throttler) Allow() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.clock.Now()
+	if !t.last.IsZero() && now.Sub(t.last) < t.interval {
+		return false
+	}
+	t.last = now
+	return true
+}
+
+// Do calls fn if the throttle allows it and reports whether it ran.
+func (t *Throttler) Do(fn func()) bool {
+	if !t.Allow() {
+		return false
+	}
+	fn()
+	return true
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestDebouncer(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	calls := 0
+	d := NewDebouncer(clock, 100*time.Millisecond, func() { calls++ })
+
+	// A burst of triggers 50ms apart keeps pushing the deadline back.
+	for i := 0; i < 5; i++ {
+		d.Trigger()
+		clock.Advance(50 * time.Millisecond)
+	}
+	if calls != 0 {
+		t.Fatalf("fired during the burst: %d calls", calls)
+	}
+	clock.Advance(50 * time.Millisecond)
+	if calls != 1 {
+		t.Fatalf("calls = %d after the quiet period, want 1", calls)
+	}
+	clock.Advance(time.Second)
+	if calls != 1 {
+		t.Errorf("fired again without a trigger: %d calls", calls)
+	}
+
+	d.Trigger()
+	d.Cancel()
+	clock.Advance(time.Second)
+	if calls != 1 {
+		t.Errorf("fired after Cancel: %d calls", calls)
+	}
+}
+
+func TestThrottler(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	th := NewThrottler(clock, time.Second)
+
+	var allowed []int
+	for i := 0; i < 25; i++ {
+		if th.Allow() {
+			allowed = append(allowed, i)
+		}
+		clock.Advance(250 * time.Millisecond)
+	}
+	// One call every 4 steps: 0, 4, 8, ...
+	want := []int{0, 4, 8, 12, 16, 20, 24}
+	if len(allowed) != len(want) {
+		t.Fatalf("allowed = %v, want %v", allowed, want)
+	}
+	for i := range want {
+		if allowed[i] != want[i] {
+			t.Fatalf("allowed = %v, want %v", allowed, want)
+		}
+	}
+
+	ran := false
+	if th.Do(func() { ran = true }) || ran {
+		t.Error("Do ran inside the interval")
+	}
+}
+
+func TestTimeoutFakeClock(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	block := make(chan struct{})
+	defer close(block)
+
+	done := make(chan bool)
+	go func() {
+		_, ok := Timeout(context.Background(), clock, time.Minute, func() int { <-block; return 1 })
+		done <- ok
+	}()
+	clock.WaitForTimer(epoch.Add(time.Minute))
+	clock.Advance(time.Minute)
+	if <-done {
+		t.Error("Timeout reported success for work that never finished")
+	}
+}
+
+func TestEveryFakeClock(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	called := make(chan time.Time)
+	release := make(chan struct{})
+	done := make(chan int)
+	go func() {
+		done <- Every(ctx, clock, time.Second, func(t time.Time) {
+			called <- t
+			<-release
+		})
+	}()
+
+	clock.WaitForTimer(epoch.Add(time.Second))
+	clock.Advance(time.Second)
+	if got := <-called; !got.Equal(epoch.Add(time.Second)) {
+		t.Errorf("first tick = %v, want %v", got, epoch.Add(time.Second))
+	}
+
+	// f is still running. The next tick fills the channel's one-element
+	// buffer and the two after it are dropped.
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Second)
+	}
+	release <- struct{}{}
+	if got := <-called; !got.Equal(epoch.Add(2 * time.Second)) {
+		t.Errorf("second tick = %v, want %v", got, epoch.Add(2*time.Second))
+	}
+	release <- struct{}{}
+
+	cancel()
+	if n := <-done; n != 2 {
+		t.Errorf("Every made %d calls, want 2", n)
+	}
+}
+
+func TestFakeTickerSkipsMissedPeriods(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	tk := clock.NewTicker(time.Second)
+	defer tk.Stop()
+
+	// One long jump delivers a single tick and re-arms on the period grid.
+	clock.Advance(3500 * time.Millisecond)
+	if got := <-tk.C(); !got.Equal(epoch.Add(time.Second)) {
+		t.Errorf("tick = %v, want %v", got, epoch.Add(time.Second))
+	}
+	clock.Advance(500 * time.Millisecond)
+	if got := <-tk.C(); !got.Equal(epoch.Add(4 * time.Second)) {
+		t.Errorf("tick = %v, want %v", got, epoch.Add(4*time.Second))
+	}
+
+	tk.Stop()
+	clock.Advance(time.Minute)
+	select {
+	case got := <-tk.C():
+		t.Errorf("stopped ticker fired at %v", got)
+	default:
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock whose time only moves when Advance is called.
+// Timers fire synchronously inside Advance, in deadline order, so tests
+// are deterministic and never sleep.
+type FakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock returns a clock stopped at start.
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	return c.addTimer(d, 0, make(chan time.Time, 1), nil)
+}
+
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	return c.addTimer(d, 0, nil, f)
+}
+
+// NewTicker returns a ticker that fires at most once per Advance. Like a
+// real ticker, it has a one-element buffer and drops ticks the receiver
+// is too slow to take, then re-arms for the next period after Now.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for FakeClock.NewTicker")
+	}
+	return fakeTicker{c.addTimer(d, d, make(chan time.Time, 1), nil)}
+}
+
+func (c *FakeClock) addTimer(d, period time.Duration, ch chan time.Time, f func()) *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, when: c.now.Add(d), period: period, ch: ch, f: f}
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	return t
+}
+
+// Advance jumps the clock forward by d, like a machine waking from sleep:
+// Now() reports the new time before any timer fires. Timers that were due
+// by then fire in deadline order; a channel timer delivers its own
+// deadline, as a late real timer would. Timers created while firing wait
+// for the next Advance, even if already due. A ticker stays pending,
+// re-armed for its first period boundary after the new time.
+func (c *FakeClock) Advance(d time.Duration) {
+	type firing struct {
+		t    *fakeTimer
+		when time.Time
+	}
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	var due []firing
+	var pending []*fakeTimer
+	for _, t := range c.timers {
+		if t.when.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		due = append(due, firing{t, t.when})
+		if t.period > 0 {
+			for !t.when.After(c.now) {
+				t.when = t.when.Add(t.period)
+			}
+			pending = append(pending, t)
+		}
+	}
+	c.timers = pending
+	c.mu.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].when.Before(due[j].when) })
+	for _, fire := range due {
+		if fire.t.f != nil {
+			fire.t.f()
+			continue
+		}
+		select {
+		case fire.t.ch <- fire.when:
+		default:
+		}
+	}
+}
+
+// WaitForTimer blocks until a timer with exactly the given deadline is
+// pending. Tests use it to know a goroutine has reached the wait they
+// expect before calling Advance; waiting for "any timer" is not enough,
+// because it may be a stale one about to be stopped.
+func (c *FakeClock) WaitForTimer(deadline time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for !c.hasTimerLocked(deadline) {
+		c.cond.Wait()
+	}
+}
+
+func (c *FakeClock) hasTimerLocked(deadline time.Time) bool {
+	for _, t := range c.timers {
+		if t.when.Equal(deadline) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeLocked drops t from the pending list and reports whether it was there.
+func (c *FakeClock) removeLocked(t *fakeTimer) bool {
+	for i, p := range c.timers {
+		if p == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	clock  *FakeClock
+	when   time.Time
+	period time.Duration // non-zero for tickers
+	ch     chan time.Time
+	f      func()
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.removeLocked(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	active := c.removeLocked(t)
+	t.when = c.now.Add(d)
+	if t.period > 0 {
+		t.period = d
+	}
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	return active
+}
+
+type fakeTicker struct{ t *fakeTimer }
+
+func (k fakeTicker) C() <-chan time.Time { return k.t.ch }
+func (k fakeTicker) Stop()               { k.t.Stop() }
+
+func (k fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for FakeClock ticker Reset")
+	}
+	k.t.Reset(d)
+}
@@ -0,0 +1,3 @@
+module github.com/XianingY/learn/go/time
+
+go 1.22
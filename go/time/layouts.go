@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Go layouts are written using one specific moment as the example:
+//
+//	Mon Jan 2 15:04:05 MST 2006  (1 2 3 4 5 6 -7)
+//
+// Each component is recognised by its value: "2006" is the year, "01" the
+// zero-padded month, "15" the 24-hour hour, and so on. Writing "YYYY-MM-DD"
+// does not work; writing "2006-01-02" does.
+const (
+	LayoutDate     = "2006-01-02"
+	LayoutDateTime = "2006-01-02 15:04:05"
+	LayoutHuman    = "Mon, 2 Jan 2006 at 3:04pm (MST)"
+	LayoutLogStamp = "2006/01/02 15:04:05.000"
+)
+
+// acceptedLayouts is tried in order by ParseFlexible. More specific
+// layouts come first so that a date-time is not cut short.
+var acceptedLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	LayoutDateTime,
+	"2006-01-02T15:04",
+	LayoutDate,
+	time.RFC1123Z,
+	time.RFC1123,
+	"2 Jan 2006",
+	"Jan 2, 2006",
+}
+
+// ErrUnrecognizedTime is returned when no accepted layout matches.
+var ErrUnrecognizedTime = errors.New("unrecognized time format")
+
+// ParseFlexible accepts several common formats. Inputs without an offset
+// are interpreted in loc, not UTC: time.Parse assumes UTC for those, which
+// is a classic source of off-by-hours bugs.
+func ParseFlexible(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range acceptedLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrUnrecognizedTime, s)
+}
+
+// FormatAll shows one instant in each of the module's layouts.
+func FormatAll(t time.Time) map[string]string {
+	return map[string]string{
+		"RFC3339":  t.Format(time.RFC3339),
+		"date":     t.Format(LayoutDate),
+		"datetime": t.Format(LayoutDateTime),
+		"human":    t.Format(LayoutHuman),
+		"log":      t.Format(LayoutLogStamp),
+		"kitchen":  t.Format(time.Kitchen),
+	}
+}
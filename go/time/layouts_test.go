@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseFlexible(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2024-03-09T14:05:07Z", time.Date(2024, 3, 9, 14, 5, 7, 0, time.UTC)},
+		{"2024-03-09T14:05:07.5+02:00", time.Date(2024, 3, 9, 12, 5, 7, 500_000_000, time.UTC)},
+		// No offset in the input: interpreted in the given location.
+		{"2024-03-09 14:05:07", time.Date(2024, 3, 9, 14, 5, 7, 0, berlin)},
+		{"2024-03-09", time.Date(2024, 3, 9, 0, 0, 0, 0, berlin)},
+		{"9 Mar 2024", time.Date(2024, 3, 9, 0, 0, 0, 0, berlin)},
+		{"Mar 9, 2024", time.Date(2024, 3, 9, 0, 0, 0, 0, berlin)},
+	}
+	for _, tt := range tests {
+		got, err := ParseFlexible(tt.in, berlin)
+		if err != nil {
+			t.Errorf("ParseFlexible(%q): %v", tt.in, err)
+			continue
+		}
+		// Equal compares instants; == would also compare locations.
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseFlexible(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	if _, err := ParseFlexible("09/03/2024", time.UTC); !errors.Is(err, ErrUnrecognizedTime) {
+		t.Errorf("err = %v, want ErrUnrecognizedTime", err)
+	}
+}
+
+func TestFormatAll(t *testing.T) {
+	got := FormatAll(time.Date(2006, 1, 2, 15, 4, 5, 0, time.FixedZone("MST", -7*3600)))
+	want := map[string]string{
+		"RFC3339":  "2006-01-02T15:04:05-07:00",
+		"date":     "2006-01-02",
+		"datetime": "2006-01-02 15:04:05",
+		"human":    "Mon, 2 Jan 2006 at 3:04pm (MST)",
+		"log":      "2006/01/02 15:04:05.000",
+		"kitchen":  "3:04PM",
+	}
+	for k, w := range want {
+		if got[k] != w {
+			t.Errorf("%s = %q, want %q", k, got[k], w)
+		}
+	}
+}
+
+func TestNextDayAcrossDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 3, 9, 9, 0, 0, 0, ny)
+	cal, elapsed := NextDay(start)
+	if cal.Hour() != 9 {
+		t.Errorf("AddDate kept hour %d, want 9", cal.Hour())
+	}
+	if elapsed.Hour() != 10 {
+		t.Errorf("Add(24h) gave hour %d, want 10", elapsed.Hour())
+	}
+	if d := cal.Sub(start); d != 23*time.Hour {
+		t.Errorf("calendar day lasted %v, want 23h", d)
+	}
+}
+
+func TestInZonesUnknown(t *testing.T) {
+	if _, err := InZones(time.Now(), "Mars/Olympus_Mons"); err == nil {
+		t.Error("expected an error for an unknown zone")
+	}
+}
+
+func TestHasMonotonic(t *testing.T) {
+	now := time.Now()
+	if !HasMonotonic(now) {
+		t.Error("time.Now() should carry a monotonic reading")
+	}
+	for name, v := range map[string]time.Time{
+		"Round(0)": now.Round(0),
+		"UTC":      now.UTC(),
+		"AddDate":  now.AddDate(0, 0, 1),
+	} {
+		if HasMonotonic(v) {
+			t.Errorf("%s kept the monotonic reading", name)
+		}
+	}
+	if !HasMonotonic(now.Add(time.Hour)) {
+		t.Error("Add should keep the monotonic reading")
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+)
+
+func main() {
+	run := flag.Bool("run", false, "run the demo scheduler until interrupted")
+	flag.Parse()
+
+	fmt.Println("== layouts ==")
+	ref := time.Date(2024, time.March, 9, 14, 5, 7, 123_000_000, time.UTC)
+	formats := FormatAll(ref)
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("%-9s %s\n", name, formats[name])
+	}
+	for _, in := range []string{"2024-03-09", "9 Mar 2024", "Mar 9, 2024", "2024-03-09T14:05"} {
+		t, err := ParseFlexible(in, time.UTC)
+		fmt.Printf("parse %-20q -> %v %v\n", in, t, err)
+	}
+
+	fmt.Println("\n== time zones ==")
+	zones, err := InZones(ref, "America/New_York", "Europe/Berlin", "Asia/Kolkata", "Australia/Adelaide")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, name := range []string{"America/New_York", "Europe/Berlin", "Asia/Kolkata", "Australia/Adelaide"} {
+		fmt.Printf("%-19s %s\n", name, zones[name].Format("2006-01-02 15:04 MST (-07:00)"))
+	}
+	ny, _ := time.LoadLocation("America/New_York")
+	beforeDST := time.Date(2024, time.March, 9, 9, 0, 0, 0, ny)
+	cal, elapsed := NextDay(beforeDST)
+	fmt.Println("across the US DST change on 2024-03-10:")
+	fmt.Println("  AddDate(0,0,1):", cal.Format(time.RFC3339))
+	fmt.Println("  Add(24h):      ", elapsed.Format(time.RFC3339))
+
+	fmt.Println("\n== monotonic clock ==")
+	now := time.Now()
+	fmt.Println("time.Now():      ", now)
+	fmt.Println("has monotonic:   ", HasMonotonic(now), "after Round(0):", HasMonotonic(now.Round(0)))
+	fmt.Println("measured sleep:  ", Elapsed(func() { time.Sleep(20 * time.Millisecond) }).Round(time.Millisecond))
+
+	fmt.Println("\n== timers vs tickers ==")
+	v, ok := Timeout(context.Background(), RealClock{}, 50*time.Millisecond, func() int { return 42 })
+	fmt.Println("fast work:", v, ok)
+	_, ok = Timeout(context.Background(), RealClock{}, 10*time.Millisecond, func() int { time.Sleep(100 * time.Millisecond); return 1 })
+	fmt.Println("slow work finished in time:", ok)
+	ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+	n := Every(ctx, RealClock{}, 10*time.Millisecond, func(time.Time) {})
+	cancel()
+	fmt.Println("ticks in 55ms at 10ms:", n)
+
+	fmt.Println("\n== debounce and throttle ==")
+	fired := make(chan struct{}, 1)
+	d := NewDebouncer(RealClock{}, 30*time.Millisecond, func() { fired <- struct{}{} })
+	for i := 0; i < 5; i++ {
+		d.Trigger()
+		time.Sleep(5 * time.Millisecond)
+	}
+	<-fired
+	fmt.Println("5 triggers -> 1 debounced call")
+	th := NewThrottler(RealClock{}, 20*time.Millisecond)
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		if th.Allow() {
+			allowed++
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	fmt.Println("10 calls over ~50ms at 1 per 20ms ->", allowed, "allowed")
+
+	fmt.Println("\n== cron schedules ==")
+	for _, spec := range []string{"*/15 * * * *", "30 9 * * 1-5", "0 0 1 */3 *", "@weekly"} {
+		sched, err := ParseSchedule(spec)
+		if err != nil {
+			log.Fatal(err)
+		}
+		t := ref
+		fmt.Printf("%-14s", spec)
+		for i := 0; i < 3; i++ {
+			t = sched.Next(t)
+			fmt.Print("  ", t.Format("Mon Jan 2 15:04"))
+		}
+		fmt.Println()
+	}
+
+	if !*run {
+		return
+	}
+	fmt.Println("\n== scheduler (Ctrl-C to stop) ==")
+	s := NewScheduler(RealClock{})
+	if err := s.Add("every-minute", "* * * * *", func(t time.Time) {
+		fmt.Println("tick", t.Format(time.TimeOnly))
+	}); err != nil {
+		log.Fatal(err)
+	}
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Println("next run:", s.NextRuns()["every-minute"].Format(time.TimeOnly))
+	_ = s.Run(sigCtx)
+}
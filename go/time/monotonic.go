@@ -0,0 +1,27 @@
+package main
+
+import "time"
+
+// time.Now returns a Time carrying two readings: the wall clock, which
+// can jump when NTP or a user changes the system time, and a monotonic
+// clock, which only moves forward. Sub, Since, and Until use the
+// monotonic reading when both operands have one, so elapsed-time
+// measurements are immune to clock changes.
+//
+// The monotonic reading is dropped by anything that produces a "new"
+// time value: Round(0), Truncate, In, UTC, Local, AddDate, parsing, and
+// serialization. Comparing such values falls back to the wall clock.
+
+// HasMonotonic reports whether t still carries a monotonic reading.
+// Printing a Time shows it as an "m=+0.000123" suffix.
+func HasMonotonic(t time.Time) bool {
+	return t != t.Round(0)
+}
+
+// Elapsed measures how long f takes. time.Since uses the monotonic clock,
+// so the result is never negative even if the wall clock moves backwards.
+func Elapsed(f func()) time.Duration {
+	start := time.Now()
+	f()
+	return time.Since(start)
+}
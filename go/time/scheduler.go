@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Scheduler runs jobs on cron schedules. Jobs run one at a time on the
+// goroutine that called Run, so a slow job delays the others; start a
+// goroutine inside the job if that matters.
+type Scheduler struct {
+	clock Clock
+
+	mu   sync.Mutex
+	jobs []*job
+	wake chan struct{}
+}
+
+type job struct {
+	name     string
+	schedule Schedule
+	fn       func(time.Time)
+	next     time.Time
+}
+
+// NewScheduler returns an empty scheduler that reads time from c.
+func NewScheduler(c Clock) *Scheduler {
+	return &Scheduler{clock: c, wake: make(chan struct{}, 1)}
+}
+
+// Add registers fn under name. fn receives the scheduled time. When runs
+// were missed and collapsed into one (see runDue), that is the earliest
+// missed slot, which may be long before the current time. Add may be
+// called while Run is active.
+func (s *Scheduler) Add(name, spec string, fn func(scheduled time.Time)) error {
+	sched, err := ParseSchedule(spec)
+	if err != nil {
+		return fmt.Errorf("job %q: %w", name, err)
+	}
+	next := sched.Next(s.clock.Now())
+	if next.IsZero() {
+		return fmt.Errorf("job %q: schedule %q never fires", name, spec)
+	}
+
+	s.mu.Lock()
+	s.jobs = append(s.jobs, &job{name: name, schedule: sched, fn: fn, next: next})
+	s.mu.Unlock()
+
+	// Let Run recompute its wait in case the new job is due sooner.
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// NextRuns reports when each job fires next, keyed by name.
+func (s *Scheduler) NextRuns() map[string]time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]time.Time, len(s.jobs))
+	for _, j := range s.jobs {
+		out[j.name] = j.next
+	}
+	return out
+}
+
+// Run executes jobs as they come due until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		// A wake left over from an Add before this point is already
+		// reflected in untilNext; acting on it later would only cancel
+		// the timer created below.
+		select {
+		case <-s.wake:
+		default:
+		}
+
+		wait, ok := s.untilNext()
+		var timerC <-chan time.Time
+		var timer Timer
+		if ok {
+			timer = s.clock.NewTimer(wait)
+			timerC = timer.C()
+		}
+
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return ctx.Err()
+		case <-s.wake:
+			if timer != nil {
+				timer.Stop()
+			}
+		case <-timerC:
+			s.runDue()
+		}
+	}
+}
+
+// untilNext returns how long until the earliest job, or false if there
+// are no jobs.
+func (s *Scheduler) untilNext() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.jobs) == 0 {
+		return 0, false
+	}
+	earliest := s.jobs[0].next
+	for _, j := range s.jobs[1:] {
+		if j.next.Before(earliest) {
+			earliest = j.next
+		}
+	}
+	return max(earliest.Sub(s.clock.Now()), 0), true
+}
+
+// runDue runs every job whose time has come and schedules its next run
+// from the current clock time, not from the deadline the timer delivered.
+// A job that missed several runs (say the machine was asleep) therefore
+// runs once, not once per missed slot.
+func (s *Scheduler) runDue() {
+	now := s.clock.Now()
+	s.mu.Lock()
+	var due []*job
+	for _, j := range s.jobs {
+		if !j.next.After(now) {
+			due = append(due, j)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, j := range due {
+		j.fn(j.next)
+		s.mu.Lock()
+		j.next = j.schedule.Next(now)
+		s.mu.Unlock()
+	}
+}
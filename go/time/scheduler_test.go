@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder collects the scheduled times a job was called with.
+type recorder struct {
+	mu    sync.Mutex
+	times []time.Time
+	ran   chan struct{}
+}
+
+func newRecorder() *recorder { return &recorder{ran: make(chan struct{}, 100)} }
+
+func (r *recorder) fn(t time.Time) {
+	r.mu.Lock()
+	r.times = append(r.times, t)
+	r.mu.Unlock()
+	r.ran <- struct{}{}
+}
+
+func (r *recorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.times)
+}
+
+// startScheduler runs s in the background and stops it when the test ends.
+func startScheduler(t *testing.T, s *Scheduler) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		s.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+}
+
+func TestSchedulerRunsOnSchedule(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 10, 7, 0, 0, time.UTC))
+	s := NewScheduler(clock)
+	quarter := newRecorder()
+	if err := s.Add("quarter", "*/15 * * * *", quarter.fn); err != nil {
+		t.Fatal(err)
+	}
+	startScheduler(t, s)
+
+	want := []string{"10:15", "10:30", "10:45", "11:00"}
+	for _, w := range want {
+		at, _ := time.Parse("15:04", w)
+		deadline := time.Date(2024, 1, 1, at.Hour(), at.Minute(), 0, 0, time.UTC)
+		clock.WaitForTimer(deadline)
+		clock.Advance(deadline.Sub(clock.Now()))
+		<-quarter.ran
+	}
+
+	quarter.mu.Lock()
+	defer quarter.mu.Unlock()
+	for i, w := range want {
+		if got := quarter.times[i].Format("15:04"); got != w {
+			t.Errorf("run %d at %s, want %s", i, got, w)
+		}
+	}
+}
+
+func TestSchedulerAddWhileRunning(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 10, 0, 30, 0, time.UTC))
+	s := NewScheduler(clock)
+	hourly := newRecorder()
+	if err := s.Add("hourly", "@hourly", hourly.fn); err != nil {
+		t.Fatal(err)
+	}
+	startScheduler(t, s)
+	clock.WaitForTimer(time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC))
+
+	// A job due much sooner than the hourly one must wake the scheduler,
+	// which replaces its hour-long timer with one for 10:01.
+	minutely := newRecorder()
+	if err := s.Add("minutely", "* * * * *", minutely.fn); err != nil {
+		t.Fatal(err)
+	}
+	clock.WaitForTimer(time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC))
+	clock.Advance(30 * time.Second)
+	<-minutely.ran
+	if hourly.count() != 0 {
+		t.Error("hourly job ran early")
+	}
+}
+
+func TestSchedulerMissedRunsCollapse(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	s := NewScheduler(clock)
+	r := newRecorder()
+	if err := s.Add("minutely", "* * * * *", r.fn); err != nil {
+		t.Fatal(err)
+	}
+	startScheduler(t, s)
+	clock.WaitForTimer(time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC))
+
+	// Sleep through ten slots in one jump.
+	clock.Advance(10 * time.Minute)
+	<-r.ran
+	// The next timer is only created once runDue has finished.
+	next := time.Date(2024, 1, 1, 10, 11, 0, 0, time.UTC)
+	clock.WaitForTimer(next)
+	if n := r.count(); n != 1 {
+		t.Errorf("ran %d times after one long gap, want 1", n)
+	}
+	if got := s.NextRuns()["minutely"]; !got.Equal(next) {
+		t.Errorf("next run = %v, want %v", got, next)
+	}
+}
+
+func TestSchedulerAddErrors(t *testing.T) {
+	s := NewScheduler(NewFakeClock(time.Now()))
+	if err := s.Add("bad", "not a spec", func(time.Time) {}); err == nil {
+		t.Error("expected a parse error")
+	}
+	if err := s.Add("never", "0 0 31 2 *", func(time.Time) {}); err == nil {
+		t.Error("expected an error for a schedule that never fires")
+	}
+}
+
+func TestSchedulerStops(t *testing.T) {
+	s := NewScheduler(NewFakeClock(time.Now()))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.Run(ctx); err != context.Canceled {
+		t.Errorf("Run = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// Timers fire once; tickers fire repeatedly. Both should be stopped when
+// no longer needed. Modules declaring go 1.23 or later get timers that are
+// garbage collected even if never stopped, but this module declares 1.22,
+// where an unstopped ticker lives forever. Stop also keeps a pending
+// AfterFunc callback from running at all.
+
+// Timeout runs f and gives up waiting after d. The timer is stopped when
+// f wins the race.
+func Timeout(ctx context.Context, c Clock, d time.Duration, f func() int) (int, bool) {
+	result := make(chan int, 1) // buffered so f's goroutine never blocks
+	go func() { result <- f() }()
+
+	t := c.NewTimer(d)
+	defer t.Stop()
+	select {
+	case v := <-result:
+		return v, true
+	case <-t.C():
+		return 0, false
+	case <-ctx.Done():
+		return 0, false
+	}
+}
+
+// Every calls f on each tick until ctx is cancelled. A ticker drops ticks
+// when the receiver is slow instead of queueing them, so a slow f never
+// causes a burst of catch-up calls. It returns the number of calls.
+func Every(ctx context.Context, c Clock, d time.Duration, f func(time.Time)) int {
+	ticker := c.NewTicker(d)
+	defer ticker.Stop()
+	n := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return n
+		case t := <-ticker.C():
+			f(t)
+			n++
+		}
+	}
+}
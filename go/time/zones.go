@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	// Embeds the IANA time zone database (about 450 KB) so LoadLocation
+	// works on machines without /usr/share/zoneinfo, such as scratch
+	// containers and some Windows installs.
+	_ "time/tzdata"
+)
+
+// InZones returns t as wall-clock time in each named zone. The instant
+// is the same; only the presentation changes.
+func InZones(t time.Time, names ...string) (map[string]time.Time, error) {
+	out := make(map[string]time.Time, len(names))
+	for _, name := range names {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("load %q: %w", name, err)
+		}
+		out[name] = t.In(loc)
+	}
+	return out, nil
+}
+
+// NextDay shows the difference between calendar and elapsed-time
+// arithmetic. Across a daylight-saving change, AddDate(0, 0, 1) keeps the
+// wall clock (09:00 stays 09:00) while Add(24h) keeps the duration.
+func NextDay(t time.Time) (calendar, elapsed time.Time) {
+	return t.AddDate(0, 0, 1), t.Add(24 * time.Hour)
+}